@@ -10,9 +10,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -22,7 +25,11 @@ import (
 	"github.com/fikryfahrezy/forward/blog-api/internal/config"
 	"github.com/fikryfahrezy/forward/blog-api/internal/database"
 	"github.com/fikryfahrezy/forward/blog-api/internal/health"
+	"github.com/fikryfahrezy/forward/blog-api/internal/jwks"
+	"github.com/fikryfahrezy/forward/blog-api/internal/lockout"
 	"github.com/fikryfahrezy/forward/blog-api/internal/logger"
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc"
 	postHandler "github.com/fikryfahrezy/forward/blog-api/internal/post/handler"
 	postRepo "github.com/fikryfahrezy/forward/blog-api/internal/post/repository"
 	postService "github.com/fikryfahrezy/forward/blog-api/internal/post/service"
@@ -52,6 +59,42 @@ func main() {
 		os.Exit(1)
 	}
 
+	jwtKeys, err := server.LoadKeySet(server.KeySetConfig{
+		Algorithm:            cfg.JWT.Algorithm,
+		SecretKey:            cfg.JWT.SecretKey,
+		SigningKeyID:         cfg.JWT.SigningKeyID,
+		SigningKeyFile:       cfg.JWT.SigningKeyFile,
+		VerificationKeyFiles: cfg.JWT.VerificationKeyFiles,
+	})
+	if err != nil {
+		log.Error("Failed to load JWT keys",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	mail, err := mailer.New(cfg.Mailer)
+	if err != nil {
+		log.Error("Failed to initialize mailer",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	loginAttempts, err := lockout.NewStore(cfg.Login.Store, db.Pool)
+	if err != nil {
+		log.Error("Failed to initialize login attempt store",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+	loginGuard := lockout.New(cfg.Login, loginAttempts)
+
+	oidcProviders := make([]*oidc.Provider, len(cfg.OIDC.Providers))
+	for i, providerConfig := range cfg.OIDC.Providers {
+		oidcProviders[i] = oidc.NewProvider(providerConfig, nil)
+	}
+
 	// Initialize repositories
 	userRepository := userRepo.New(db.Pool)
 	postRepository := postRepo.New(db.Pool)
@@ -59,14 +102,53 @@ func main() {
 
 	// Initialize services
 	userSvc := userService.New(
-		server.NewJWTGenerator(cfg.JWT.SecretKey, cfg.JWT.TokenDuration),
+		userService.Config{
+			RefreshTokenDuration:       cfg.JWT.RefreshTokenDuration,
+			RevocationCacheTTL:         cfg.JWT.RevocationCacheTTL,
+			RevocationCleanupInterval:  cfg.JWT.RevocationCleanupInterval,
+			PasswordResetTokenDuration: cfg.Auth.PasswordResetTokenDuration,
+			PasswordResetURL:           cfg.Auth.PasswordResetURL,
+			VerificationTokenDuration:  cfg.Auth.VerificationTokenDuration,
+			VerificationURL:            cfg.Auth.VerificationURL,
+			VerificationResendCooldown: cfg.Auth.VerificationResendCooldown,
+			VerificationResendLimit:    cfg.Auth.VerificationResendLimit,
+			DeleteContentWithAccount:   cfg.Auth.DeleteContentWithAccount,
+			TOTPIssuer:                 cfg.Auth.TOTPIssuer,
+			LoginChallengeDuration:     cfg.Auth.LoginChallengeDuration,
+			OIDCProviders:              oidcProviders,
+			OIDCStateDuration:          cfg.OIDC.StateDuration,
+			PasswordMinLength:          cfg.Auth.PasswordMinLength,
+			PasswordHashing:            cfg.Password,
+		},
+		server.NewJWTGenerator(server.JWTGeneratorConfig{
+			Keys:          jwtKeys,
+			TokenDuration: cfg.JWT.TokenDuration,
+			Issuer:        cfg.JWT.Issuer,
+			Audience:      cfg.JWT.Audience,
+		}),
 		userRepository,
+		mail,
+		loginGuard,
 	)
-	postSvc := postService.New(postRepository)
+	postSvc := postService.New(
+		postService.Config{
+			SchedulerInterval: cfg.Post.SchedulerInterval,
+			SearchLanguage:    cfg.Post.SearchLanguage,
+		},
+		postRepository,
+	)
+	if err := postSvc.CheckSearchLanguage(context.Background()); err != nil {
+		log.Error("Invalid post search language",
+			slog.String("language", cfg.Post.SearchLanguage),
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
 	commentSvc := commentService.New(commentRepository)
 
 	// Initialize handlers
 	healthHdl := health.NewHealthHandler(db)
+	jwksHdl := jwks.NewJWKSHandler(jwtKeys)
 	userHdl := userHandler.New(userSvc)
 	postHdl := postHandler.New(postSvc)
 	commentHdl := commentHandler.New(commentSvc)
@@ -79,20 +161,37 @@ func main() {
 
 	// Configure JWT middleware
 	jwtMiddleware := server.NewJWTMiddleware(server.JWTConfig{
-		SecretKey: cfg.JWT.SecretKey,
+		Keys:                 jwtKeys,
+		Issuer:               cfg.JWT.Issuer,
+		Audience:             cfg.JWT.Audience,
+		Leeway:               cfg.JWT.Leeway,
+		TokenValidator:       userSvc,
+		PersonalAccessTokens: userSvc,
+		RequireVerifiedEmail: cfg.Auth.RequireVerifiedEmail,
+		EmailVerification:    userSvc,
 	})
 	srv.SetJWTMiddleware(jwtMiddleware)
 
+	// Start background jobs
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	background.Go(func() { userSvc.RunRevocationCleanup(bgCtx) })
+	background.Go(func() { loginGuard.RunCleanup(bgCtx) })
+	background.Go(func() { postSvc.RunScheduler(bgCtx) })
+
 	// Register route handlers
 	routeHandlers := []server.RouteHandler{
 		healthHdl,
+		jwksHdl,
 		userHdl,
 		postHdl,
 		commentHdl,
 	}
 
 	go func() {
-		if err := srv.Start(routeHandlers); err != nil {
+		// Stopping the server returns ErrServerClosed, the shutdown below
+		// carries on from there
+		if err := srv.Start(routeHandlers); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Error("Server error",
 				slog.String("error", err.Error()),
 			)
@@ -118,6 +217,11 @@ func main() {
 		)
 	}
 
+	// Stop background jobs, waiting for a running one such as publishing
+	// scheduled posts to finish before the database is closed
+	stopBackground()
+	background.Wait()
+
 	// Close database connection
 	db.Close()
 	log.Info("Application shutdown complete")
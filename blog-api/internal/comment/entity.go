@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
 )
 
 type Comment struct {
@@ -31,10 +33,9 @@ type CreateCommentRequest struct {
 }
 
 func (r CreateCommentRequest) Validate() error {
-	if r.Content == "" {
-		return ErrInvalidInput
-	}
-	return nil
+	v := validation.Validator{}
+	v.Required("content", r.Content)
+	return v.Err()
 }
 
 type UpdateCommentRequest struct {
@@ -42,10 +43,9 @@ type UpdateCommentRequest struct {
 }
 
 func (r UpdateCommentRequest) Validate() error {
-	if r.Content == "" {
-		return ErrInvalidInput
-	}
-	return nil
+	v := validation.Validator{}
+	v.Required("content", r.Content)
+	return v.Err()
 }
 
 type CommentItem struct {
@@ -59,10 +59,16 @@ type CommentItem struct {
 }
 
 type CommentListResponse struct {
-	Comments   []CommentItem `json:"comments"`
-	TotalCount int           `json:"total_count" example:"50"`
-	Page       int           `json:"page" example:"1"`
-	PageSize   int           `json:"page_size" example:"10"`
+	Comments []CommentItem `json:"comments"`
+	// TotalCount is only counted when asked for with `include_total=true`.
+	TotalCount *int `json:"total_count,omitempty" example:"50"`
+	// Page is left out for pages fetched by cursor.
+	Page     int `json:"page,omitempty" example:"1"`
+	PageSize int `json:"page_size" example:"10"`
+	// NextCursor and PrevCursor fetch the pages around this one when passed
+	// as `cursor`, they are left out when there is no such page.
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjU1MGU4NDAwLWUyOWItNDFkNC1hNzE2LTQ0NjY1NTQ0MDAwMCJ9"`
+	PrevCursor string `json:"prev_cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjU1MGU4NDAwLWUyOWItNDFkNC1hNzE2LTQ0NjY1NTQ0MDAwMCIsImIiOnRydWV9"`
 }
 
 func (c *CommentWithAuthor) ToCommentItem() CommentItem {
@@ -1,12 +1,14 @@
 package comment
 
 import (
+	"net/http"
+
 	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
 )
 
 var (
-	ErrCommentNotFound = appError.New("COMMENT_NOT_FOUND", "Comment not found")
-	ErrPostNotFound    = appError.New("POST_NOT_FOUND", "Post not found")
-	ErrInvalidInput    = appError.New("INVALID_INPUT", "Invalid input data")
-	ErrUnauthorized    = appError.New("UNAUTHORIZED", "You are not authorized to perform this action")
+	ErrCommentNotFound = appError.New(http.StatusNotFound, "COMMENT_NOT_FOUND", "Comment not found")
+	ErrPostNotFound    = appError.New(http.StatusNotFound, "POST_NOT_FOUND", "Post not found")
+	ErrInvalidInput    = appError.New(http.StatusUnprocessableEntity, "INVALID_INPUT", "Invalid input data")
+	ErrUnauthorized    = appError.New(http.StatusForbidden, "UNAUTHORIZED", "You are not authorized to perform this action")
 )
@@ -14,7 +14,7 @@ import (
 func TestCreateComment_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content for comments")
 
 	reqBody := comment.CreateCommentRequest{
@@ -45,7 +45,7 @@ func TestCreateComment_Success(t *testing.T) {
 func TestCreateComment_Unauthorized(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "author", "author@example.com", "password123")
+	token := registerAndGetToken(t, "author", "author@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content")
 
 	reqBody := comment.CreateCommentRequest{
@@ -66,7 +66,7 @@ func TestCreateComment_Unauthorized(t *testing.T) {
 func TestCreateComment_InvalidInput(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content")
 
 	reqBody := comment.CreateCommentRequest{
@@ -88,7 +88,7 @@ func TestCreateComment_InvalidInput(t *testing.T) {
 func TestCreateComment_PostNotFound(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 
 	reqBody := comment.CreateCommentRequest{
 		Content: "Comment on non-existent post",
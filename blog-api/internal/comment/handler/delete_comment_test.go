@@ -8,13 +8,14 @@ import (
 	"testing"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
 func TestDeleteComment_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content")
 
 	createReq := comment.CreateCommentRequest{
@@ -60,7 +61,7 @@ func TestDeleteComment_Success(t *testing.T) {
 func TestDeleteComment_NotAuthor(t *testing.T) {
 	cleanup(t)
 
-	token1 := registerAndGetToken(t, "author1", "author1@example.com", "password123")
+	token1 := registerAndGetToken(t, "author1", "author1@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token1, "Test Post", "Test content")
 
 	createReq := comment.CreateCommentRequest{
@@ -80,7 +81,7 @@ func TestDeleteComment_NotAuthor(t *testing.T) {
 	result := createResponse.Result.(map[string]any)
 	commentID := result["id"].(string)
 
-	token2 := registerAndGetToken(t, "author2", "author2@example.com", "password123")
+	token2 := registerAndGetToken(t, "author2", "author2@example.com", "Str0ng-Passw0rd")
 
 	req = httptest.NewRequest(http.MethodDelete, "/api/v1/comments/"+commentID, nil)
 	req.Header.Set("Authorization", "Bearer "+token2)
@@ -92,6 +93,41 @@ func TestDeleteComment_NotAuthor(t *testing.T) {
 	}
 }
 
+func TestDeleteComment_Moderator(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "author1", "author1@example.com", "Str0ng-Passw0rd")
+	postID := createPost(t, authorToken, "Test Post", "Test content")
+
+	createReq := comment.CreateCommentRequest{
+		Content: "Comment to moderate",
+	}
+	body, _ := json.Marshal(createReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts/"+postID+"/comments", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	var createResponse server.APIResponse
+	//nolint:errcheck
+	json.Unmarshal(rec.Body.Bytes(), &createResponse)
+	result := createResponse.Result.(map[string]any)
+	commentID := result["id"].(string)
+
+	moderatorToken := registerWithRole(t, "moderator", "moderator@example.com", "Str0ng-Passw0rd", policy.RoleModerator)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/comments/"+commentID, nil)
+	req.Header.Set("Authorization", "Bearer "+moderatorToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
 func TestDeleteComment_Unauthorized(t *testing.T) {
 	cleanup(t)
 
@@ -107,7 +143,7 @@ func TestDeleteComment_Unauthorized(t *testing.T) {
 func TestDeleteComment_NotFound(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/comments/550e8400-e29b-41d4-a716-446655440000", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
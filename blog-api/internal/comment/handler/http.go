@@ -1,10 +1,8 @@
 package handler
 
 import (
-	"net/http"
-
-	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment/service"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
@@ -18,25 +16,12 @@ func New(svc *service.Service) *Handler {
 	}
 }
 
-func (h *Handler) SetupRoutes(server *server.Server) {
+func (h *Handler) SetupRoutes(srv *server.Server) {
 	// Public routes
-	server.HandleFunc("GET /api/v1/posts/{postId}/comments", h.ListComments)
+	srv.HandleFunc("GET /api/v1/posts/{postId}/comments", h.ListComments)
 
 	// Protected routes
-	server.HandleFuncWithAuth("POST /api/v1/posts/{postId}/comments", h.CreateComment)
-	server.HandleFuncWithAuth("PUT /api/v1/comments/{commentId}", h.UpdateComment)
-	server.HandleFuncWithAuth("DELETE /api/v1/comments/{commentId}", h.DeleteComment)
-}
-
-func (h *Handler) handleError(w http.ResponseWriter, err error) {
-	switch err {
-	case comment.ErrInvalidInput:
-		server.ErrorResponse(w, http.StatusUnprocessableEntity, "", err)
-	case comment.ErrCommentNotFound, comment.ErrPostNotFound:
-		server.ErrorResponse(w, http.StatusNotFound, "", err)
-	case comment.ErrUnauthorized:
-		server.ErrorResponse(w, http.StatusForbidden, "", err)
-	default:
-		server.ErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-	}
+	srv.HandleFuncWithAuth("POST /api/v1/posts/{postId}/comments", h.CreateComment, server.RequireVerifiedEmail(), server.RequireScope(policy.ScopeCommentsWrite))
+	srv.HandleFuncWithAuth("PUT /api/v1/comments/{commentId}", h.UpdateComment, server.RequireScope(policy.ScopeCommentsWrite))
+	srv.HandleFuncWithAuth("DELETE /api/v1/comments/{commentId}", h.DeleteComment, server.RequireScope(policy.ScopeCommentsWrite))
 }
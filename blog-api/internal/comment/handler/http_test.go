@@ -28,7 +28,10 @@ import (
 	commentHandler "github.com/fikryfahrezy/forward/blog-api/internal/comment/handler"
 	commentRepository "github.com/fikryfahrezy/forward/blog-api/internal/comment/repository"
 	commentService "github.com/fikryfahrezy/forward/blog-api/internal/comment/service"
+	"github.com/fikryfahrezy/forward/blog-api/internal/lockout"
 	"github.com/fikryfahrezy/forward/blog-api/internal/logger"
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	postHandler "github.com/fikryfahrezy/forward/blog-api/internal/post/handler"
 	postRepository "github.com/fikryfahrezy/forward/blog-api/internal/post/repository"
@@ -49,11 +52,13 @@ var (
 )
 
 const (
-	testJWTSecret   = "test-secret-key-for-integration-tests"
-	testTokenExpiry = 24 * time.Hour
-	testDBUser      = "test"
-	testDBPassword  = "test"
-	testDBName      = "testdb"
+	testJWTSecret     = "test-secret-key-for-integration-tests"
+	testTokenExpiry   = 24 * time.Hour
+	testRefreshExpiry = 7 * 24 * time.Hour
+	testRevocationTTL = time.Minute
+	testDBUser        = "test"
+	testDBPassword    = "test"
+	testDBName        = "testdb"
 )
 
 func TestMain(m *testing.M) {
@@ -187,12 +192,25 @@ func runMigrations(databaseURL string) error {
 
 func setupTestHandlers() {
 	logger.NewLogger(logger.Config{}, io.Discard)
+	testJWTKeys := server.NewHMACKeySet(testJWTSecret)
 	userRepo := userRepository.New(testPool)
-	userSvc := userService.New(server.NewJWTGenerator(testJWTSecret, testTokenExpiry), userRepo)
+	userSvc := userService.New(
+		userService.Config{
+			RefreshTokenDuration: testRefreshExpiry,
+			RevocationCacheTTL:   testRevocationTTL,
+		},
+		server.NewJWTGenerator(server.JWTGeneratorConfig{
+			Keys:          testJWTKeys,
+			TokenDuration: testTokenExpiry,
+		}),
+		userRepo,
+		mailer.NewLogMailer("no-reply@example.com"),
+		lockout.New(lockout.Config{}, lockout.NewMemoryStore()),
+	)
 	testUserHandler = userHandler.New(userSvc)
 
 	postRepo := postRepository.New(testPool)
-	postSvc := postService.New(postRepo)
+	postSvc := postService.New(postService.Config{}, postRepo)
 	testPostHandler = postHandler.New(postSvc)
 
 	commentRepo := commentRepository.New(testPool)
@@ -200,7 +218,11 @@ func setupTestHandlers() {
 	testCommentHandler = commentHandler.New(commentSvc)
 
 	testServer = server.New(server.Config{Host: "localhost", Port: 8080})
-	testServer.SetJWTMiddleware(server.NewJWTMiddleware(server.JWTConfig{SecretKey: testJWTSecret}))
+	testServer.SetJWTMiddleware(server.NewJWTMiddleware(server.JWTConfig{
+		Keys:                 testJWTKeys,
+		TokenValidator:       userSvc,
+		PersonalAccessTokens: userSvc,
+	}))
 	testUserHandler.SetupRoutes(testServer)
 	testPostHandler.SetupRoutes(testServer)
 	testCommentHandler.SetupRoutes(testServer)
@@ -250,6 +272,35 @@ func registerAndGetToken(t *testing.T, username, email, password string) string
 	return result["token"].(string)
 }
 
+// registerWithRole creates a test user with the given role and returns an auth
+// token carrying that role.
+func registerWithRole(t *testing.T, username, email, password string, role policy.Role) string {
+	t.Helper()
+
+	registerAndGetToken(t, username, email, password)
+	if _, err := testPool.Exec(context.Background(), "UPDATE users SET role = $1 WHERE email = $2", role, email); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	body, _ := json.Marshal(user.LoginRequest{Email: email, Password: password})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Failed to login: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	result := response.Result.(map[string]any)
+	return result["token"].(string)
+}
+
 func createPost(t *testing.T, token, title, content string) string {
 	t.Helper()
 
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
@@ -15,7 +17,7 @@ import (
 func TestListComments_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content")
 
 	for i := 1; i <= 3; i++ {
@@ -58,7 +60,7 @@ func TestListComments_Success(t *testing.T) {
 func TestListComments_Pagination(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content")
 
 	for i := 1; i <= 5; i++ {
@@ -74,7 +76,7 @@ func TestListComments_Pagination(t *testing.T) {
 		testServer.Mux().ServeHTTP(rec, req)
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/"+postID+"/comments?page=1&page_size=2", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/"+postID+"/comments?page=1&page_size=2&include_total=true", nil)
 	rec := httptest.NewRecorder()
 	testServer.Mux().ServeHTTP(rec, req)
 
@@ -108,3 +110,52 @@ func TestListComments_PostNotFound(t *testing.T) {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
 	}
 }
+
+func TestListComments_Cursor(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
+	postID := createPost(t, token, "Test Post", "Test content")
+
+	for i := 1; i <= 3; i++ {
+		body, _ := json.Marshal(comment.CreateCommentRequest{Content: fmt.Sprintf("Comment %d", i)})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/posts/"+postID+"/comments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		testServer.Mux().ServeHTTP(rec, req)
+	}
+
+	contents := []string{}
+	url := "/api/v1/posts/" + postID + "/comments?page_size=2"
+	for url != "" {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		rec := httptest.NewRecorder()
+		testServer.Mux().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var response struct {
+			Result comment.CommentListResponse `json:"result"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		for _, c := range response.Result.Comments {
+			contents = append(contents, c.Content)
+		}
+
+		// Follow the Link header like a client would
+		url = ""
+		if link := rec.Header().Get("Link"); strings.Contains(link, `rel="next"`) {
+			url = link[1:strings.Index(link, ">")]
+		}
+	}
+
+	expected := []string{"Comment 1", "Comment 2", "Comment 3"}
+	if !slices.Equal(contents, expected) {
+		t.Errorf("Expected comments %v, got %v", expected, contents)
+	}
+}
@@ -2,10 +2,10 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/google/uuid"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
@@ -14,42 +14,38 @@ import (
 // @Description  Get a paginated list of comments for a specific post
 // @Tags         comments
 // @Produce      json
-// @Param        postId    path      string  true   "Post ID"
-// @Param        page      query     int     false  "Page number"  default(1)
-// @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200       {object}  server.APIResponse{message=string,error=string,result=comment.CommentListResponse}  "Comments retrieved successfully"
-// @Failure      404       {object}  server.APIResponse{message=string,error=string}                                     "Post not found"
-// @Failure      500       {object}  server.APIResponse{message=string,error=string}                                     "Internal server error"
+// @Param        postId         path      string  true   "Post ID"
+// @Param        page           query     int     false  "Page number, ignored with a cursor"  default(1)
+// @Param        page_size      query     int     false  "Page size"                           default(10)
+// @Param        cursor         query     string  false  "Cursor of the page from next_cursor or prev_cursor"
+// @Param        include_total  query     bool    false  "Count the comments of all pages"
+// @Success      200            {object}  server.APIResponse{message=string,error=string,result=comment.CommentListResponse}  "Comments retrieved successfully"
+// @Failure      400            {object}  server.APIResponse{message=string,error=string}                                     "Invalid post ID or cursor"
+// @Failure      404            {object}  server.APIResponse{message=string,error=string}                                     "Post not found"
+// @Failure      500            {object}  server.APIResponse{message=string,error=string}                                     "Internal server error"
 // @Router       /api/v1/posts/{postId}/comments [get]
 func (h *Handler) ListComments(w http.ResponseWriter, r *http.Request) {
 	postIDStr := r.PathValue("postId")
 	postID, err := uuid.Parse(postIDStr)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid post ID", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid post ID", nil)
 		return
 	}
 
-	page := 1
-	pageSize := 10
-
-	if p := r.URL.Query().Get("page"); p != "" {
-		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
-			page = parsed
-		}
-	}
-
-	if ps := r.URL.Query().Get("page_size"); ps != "" {
-		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 {
-			pageSize = parsed
-		}
+	p, err := pagination.FromRequest(r)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
 	}
 
-	result, err := h.service.ListByPostID(r.Context(), postID, page, pageSize)
+	result, err := h.service.ListByPostID(r.Context(), postID, p)
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
+	pagination.SetLinks(w, r, result.NextCursor, result.PrevCursor)
+
 	server.JSON(w, http.StatusOK, server.APIResponse{
 		Message: "Comments retrieved successfully",
 		Result:  result,
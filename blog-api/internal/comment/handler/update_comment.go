@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
@@ -29,32 +30,33 @@ import (
 func (h *Handler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	claims, ok := server.GetUserClaims(r.Context())
 	if !ok {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
 		return
 	}
 
-	authorID, err := uuid.Parse(claims.UserID)
+	userID, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Invalid user ID", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
 		return
 	}
+	actor := policy.Actor{UserID: userID, Role: claims.Role}
 
 	commentIDStr := r.PathValue("commentId")
 	commentID, err := uuid.Parse(commentIDStr)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid comment ID", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid comment ID", nil)
 		return
 	}
 
 	var req comment.UpdateCommentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
 		return
 	}
 
-	c, err := h.service.Update(r.Context(), commentID, authorID, req)
+	c, err := h.service.Update(r.Context(), commentID, actor, req)
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
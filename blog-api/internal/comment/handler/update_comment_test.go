@@ -14,7 +14,7 @@ import (
 func TestUpdateComment_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token, "Test Post", "Test content")
 
 	createReq := comment.CreateCommentRequest{
@@ -62,7 +62,7 @@ func TestUpdateComment_Success(t *testing.T) {
 func TestUpdateComment_NotAuthor(t *testing.T) {
 	cleanup(t)
 
-	token1 := registerAndGetToken(t, "author1", "author1@example.com", "password123")
+	token1 := registerAndGetToken(t, "author1", "author1@example.com", "Str0ng-Passw0rd")
 	postID := createPost(t, token1, "Test Post", "Test content")
 
 	createReq := comment.CreateCommentRequest{
@@ -82,7 +82,7 @@ func TestUpdateComment_NotAuthor(t *testing.T) {
 	result := createResponse.Result.(map[string]any)
 	commentID := result["id"].(string)
 
-	token2 := registerAndGetToken(t, "author2", "author2@example.com", "password123")
+	token2 := registerAndGetToken(t, "author2", "author2@example.com", "Str0ng-Passw0rd")
 
 	updateReq := comment.UpdateCommentRequest{
 		Content: "Hacked comment",
@@ -121,7 +121,7 @@ func TestUpdateComment_Unauthorized(t *testing.T) {
 func TestUpdateComment_NotFound(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "commenter", "commenter@example.com", "password123")
+	token := registerAndGetToken(t, "commenter", "commenter@example.com", "Str0ng-Passw0rd")
 
 	updateReq := comment.UpdateCommentRequest{
 		Content: "Updated content",
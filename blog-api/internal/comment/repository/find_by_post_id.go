@@ -6,10 +6,25 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
 )
 
-func (r *Repository) FindByPostID(ctx context.Context, postID uuid.UUID, page, pageSize int) ([]comment.CommentWithAuthor, int, error) {
-	offset := (page - 1) * pageSize
+// FindByPostID returns up to p.Limit() comments of a post from the oldest,
+// rows before a cursor come from the newest.
+func (r *Repository) FindByPostID(ctx context.Context, postID uuid.UUID, p pagination.Request) ([]comment.CommentWithAuthor, error) {
+	condition, order := "TRUE", "ASC"
+	args := []any{postID, p.Limit(), p.Offset()}
+	if !p.Cursor.IsZero() {
+		createdAt, err := p.Cursor.Time()
+		if err != nil {
+			return nil, err
+		}
+		condition = "(c.created_at, c.id) > ($4, $5)"
+		if p.Cursor.Before {
+			condition, order = "(c.created_at, c.id) < ($4, $5)", "DESC"
+		}
+		args = append(args, createdAt, p.Cursor.ID)
+	}
 
 	query := `
 		SELECT
@@ -19,23 +34,22 @@ func (r *Repository) FindByPostID(ctx context.Context, postID uuid.UUID, page, p
 			c.author_id,
 			c.created_at,
 			c.updated_at,
-			u.username,
-			COUNT(*) OVER() AS total_count
+			CASE WHEN u.deleted_at IS NULL THEN u.username ELSE 'deleted user' END AS username
 		FROM comments c
 			JOIN users u ON c.author_id = u.id
 		WHERE
 			c.post_id = $1
 			AND c.deleted_at IS NULL
-		ORDER BY c.created_at ASC
+			AND ` + condition + `
+		ORDER BY c.created_at ` + order + `, c.id ` + order + `
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.Query(ctx, query, postID, pageSize, offset)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	var totalCount int
 	var comments []comment.CommentWithAuthor
 	for rows.Next() {
 		var c comment.CommentWithAuthor
@@ -47,12 +61,25 @@ func (r *Repository) FindByPostID(ctx context.Context, postID uuid.UUID, page, p
 			&c.CreatedAt,
 			&c.UpdatedAt,
 			&c.AuthorUsername,
-			&totalCount,
 		); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		comments = append(comments, c)
 	}
 
-	return comments, totalCount, nil
+	return comments, rows.Err()
+}
+
+// CountByPostID returns the number of comments of a post.
+func (r *Repository) CountByPostID(ctx context.Context, postID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM comments
+		WHERE
+			post_id = $1
+			AND deleted_at IS NULL
+	`
+	var count int
+	err := r.db.QueryRow(ctx, query, postID).Scan(&count)
+	return count, err
 }
@@ -6,10 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// PostExists reports whether the post exists and is published, unpublished
+// posts cannot be commented on.
 func (r *Repository) PostExists(ctx context.Context, postID uuid.UUID) (bool, error) {
 	query := `
 		SELECT EXISTS(
-			SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL
+			SELECT 1 FROM posts WHERE id = $1 AND status = 'published' AND deleted_at IS NULL
 		)
 	`
 	var exists bool
@@ -6,9 +6,10 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 )
 
-func (s *Service) Delete(ctx context.Context, commentID, authorID uuid.UUID) (comment.CommentID, error) {
+func (s *Service) Delete(ctx context.Context, commentID uuid.UUID, actor policy.Actor) (comment.CommentID, error) {
 	c, err := s.repo.FindByID(ctx, commentID)
 	if err != nil {
 		return comment.CommentID{}, err
@@ -17,8 +18,7 @@ func (s *Service) Delete(ctx context.Context, commentID, authorID uuid.UUID) (co
 		return comment.CommentID{}, comment.ErrCommentNotFound
 	}
 
-	// Check if the user is the author
-	if c.AuthorID != authorID {
+	if !policy.CanDelete(actor, c.AuthorID) {
 		return comment.CommentID{}, comment.ErrUnauthorized
 	}
 
@@ -6,9 +6,13 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
 )
 
-func (s *Service) ListByPostID(ctx context.Context, postID uuid.UUID, page, pageSize int) (comment.CommentListResponse, error) {
+// sortOrder is the order comments are listed in, from the oldest.
+const sortOrder = "created_at.asc"
+
+func (s *Service) ListByPostID(ctx context.Context, postID uuid.UUID, p pagination.Request) (comment.CommentListResponse, error) {
 	// Check if post exists
 	exists, err := s.repo.PostExists(ctx, postID)
 	if err != nil {
@@ -18,27 +22,39 @@ func (s *Service) ListByPostID(ctx context.Context, postID uuid.UUID, page, page
 		return comment.CommentListResponse{}, comment.ErrPostNotFound
 	}
 
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	p.Normalize()
+	if err := p.CheckSort(sortOrder); err != nil {
+		return comment.CommentListResponse{}, err
 	}
 
-	comments, totalCount, err := s.repo.FindByPostID(ctx, postID, page, pageSize)
+	comments, err := s.repo.FindByPostID(ctx, postID, p)
 	if err != nil {
 		return comment.CommentListResponse{}, err
 	}
+	page := pagination.Paginate(p, comments, func(c comment.CommentWithAuthor) pagination.Cursor {
+		return pagination.Cursor{Sort: sortOrder, Key: pagination.TimeKey(c.CreatedAt), ID: c.ID}
+	})
 
-	commentItems := make([]comment.CommentItem, len(comments))
-	for i, c := range comments {
+	commentItems := make([]comment.CommentItem, len(page.Rows))
+	for i, c := range page.Rows {
 		commentItems[i] = c.ToCommentItem()
 	}
 
-	return comment.CommentListResponse{
+	response := comment.CommentListResponse{
 		Comments:   commentItems,
-		TotalCount: totalCount,
-		Page:       page,
-		PageSize:   pageSize,
-	}, nil
+		PageSize:   p.PageSize,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	if p.Cursor.IsZero() {
+		response.Page = p.Page
+	}
+	if p.IncludeTotal {
+		totalCount, err := s.repo.CountByPostID(ctx, postID)
+		if err != nil {
+			return comment.CommentListResponse{}, err
+		}
+		response.TotalCount = &totalCount
+	}
+	return response, nil
 }
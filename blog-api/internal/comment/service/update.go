@@ -6,9 +6,10 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/comment"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 )
 
-func (s *Service) Update(ctx context.Context, commentID, authorID uuid.UUID, req comment.UpdateCommentRequest) (comment.CommentID, error) {
+func (s *Service) Update(ctx context.Context, commentID uuid.UUID, actor policy.Actor, req comment.UpdateCommentRequest) (comment.CommentID, error) {
 	if err := req.Validate(); err != nil {
 		return comment.CommentID{}, err
 	}
@@ -21,8 +22,7 @@ func (s *Service) Update(ctx context.Context, commentID, authorID uuid.UUID, req
 		return comment.CommentID{}, comment.ErrCommentNotFound
 	}
 
-	// Check if the user is the author
-	if c.AuthorID != authorID {
+	if !policy.CanUpdate(actor, c.AuthorID) {
 		return comment.CommentID{}, comment.ErrUnauthorized
 	}
 
@@ -9,13 +9,65 @@ import (
 	"time"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/database"
+	"github.com/fikryfahrezy/forward/blog-api/internal/lockout"
 	"github.com/fikryfahrezy/forward/blog-api/internal/logger"
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc"
+	"github.com/fikryfahrezy/forward/blog-api/internal/password"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
 type JWTConfig struct {
-	SecretKey     string
-	TokenDuration time.Duration
+	Algorithm                 string
+	SecretKey                 string
+	SigningKeyID              string
+	SigningKeyFile            string
+	VerificationKeyFiles      map[string]string
+	Issuer                    string
+	Audience                  string
+	Leeway                    time.Duration
+	TokenDuration             time.Duration
+	RefreshTokenDuration      time.Duration
+	RevocationCacheTTL        time.Duration
+	RevocationCleanupInterval time.Duration
+}
+
+type AuthConfig struct {
+	PasswordResetTokenDuration time.Duration
+	PasswordResetURL           string
+	VerificationTokenDuration  time.Duration
+	VerificationURL            string
+	VerificationResendCooldown time.Duration
+	VerificationResendLimit    int
+	// RequireVerifiedEmail blocks creating posts and comments until the
+	// user has verified their email, it is off unless turned on.
+	RequireVerifiedEmail bool
+	// DeleteContentWithAccount deletes a user's posts and comments together
+	// with the account, otherwise they are kept and anonymized.
+	DeleteContentWithAccount bool
+	// TOTPIssuer names the service in the user's authenticator app.
+	TOTPIssuer string
+	// LoginChallengeDuration is how long the second login step can be
+	// completed for accounts with two-factor authentication.
+	LoginChallengeDuration time.Duration
+	// PasswordMinLength is the minimum number of characters of a new
+	// password.
+	PasswordMinLength int
+}
+
+type PostConfig struct {
+	// SchedulerInterval is how often scheduled posts are published once
+	// due.
+	SchedulerInterval time.Duration
+	// SearchLanguage is the Postgres text search configuration posts are
+	// indexed and searched with.
+	SearchLanguage string
+}
+
+type OIDCConfig struct {
+	Providers []oidc.Config
+	// StateDuration is how long the user has to log in at the provider.
+	StateDuration time.Duration
 }
 
 type Config struct {
@@ -23,6 +75,12 @@ type Config struct {
 	Database database.Config
 	Logger   logger.Config
 	JWT      JWTConfig
+	Auth     AuthConfig
+	Mailer   mailer.Config
+	Login    lockout.Config
+	OIDC     OIDCConfig
+	Password password.Config
+	Post     PostConfig
 }
 
 func Load() Config {
@@ -41,12 +99,97 @@ func Load() Config {
 			Format: logger.ParseFormat(getEnv("LOG_FORMAT", "text")),
 		},
 		JWT: JWTConfig{
-			SecretKey:     getEnv("JWT_SECRET_KEY", "your-super-secret-key-change-in-production"),
-			TokenDuration: getEnvAsDuration("JWT_TOKEN_DURATION", 24*time.Hour),
+			Algorithm:                 getEnv("JWT_SIGNING_ALGORITHM", "HS256"),
+			SecretKey:                 getEnv("JWT_SECRET_KEY", "your-super-secret-key-change-in-production"),
+			SigningKeyID:              getEnv("JWT_SIGNING_KEY_ID", ""),
+			SigningKeyFile:            getEnv("JWT_SIGNING_KEY_FILE", ""),
+			VerificationKeyFiles:      getEnvAsMap("JWT_VERIFICATION_KEY_FILES"),
+			Issuer:                    getEnv("JWT_ISSUER", "blog-api"),
+			Audience:                  getEnv("JWT_AUDIENCE", "blog-api"),
+			Leeway:                    getEnvAsDuration("JWT_LEEWAY", 30*time.Second),
+			TokenDuration:             getEnvAsDuration("JWT_TOKEN_DURATION", 15*time.Minute),
+			RefreshTokenDuration:      getEnvAsDuration("JWT_REFRESH_TOKEN_DURATION", 7*24*time.Hour),
+			RevocationCacheTTL:        getEnvAsDuration("JWT_REVOCATION_CACHE_TTL", 30*time.Second),
+			RevocationCleanupInterval: getEnvAsDuration("JWT_REVOCATION_CLEANUP_INTERVAL", time.Hour),
+		},
+		Auth: AuthConfig{
+			PasswordResetTokenDuration: getEnvAsDuration("AUTH_PASSWORD_RESET_TOKEN_DURATION", time.Hour),
+			PasswordResetURL:           getEnv("AUTH_PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+			VerificationTokenDuration:  getEnvAsDuration("AUTH_VERIFICATION_TOKEN_DURATION", 24*time.Hour),
+			VerificationURL:            getEnv("AUTH_VERIFICATION_URL", "http://localhost:8080/api/v1/auth/verify"),
+			VerificationResendCooldown: getEnvAsDuration("AUTH_VERIFICATION_RESEND_COOLDOWN", time.Minute),
+			VerificationResendLimit:    getEnvAsInt("AUTH_VERIFICATION_RESEND_LIMIT", 5),
+			RequireVerifiedEmail:       getEnvAsBool("AUTH_REQUIRE_VERIFIED_EMAIL", false),
+			DeleteContentWithAccount:   getEnvAsBool("AUTH_DELETE_CONTENT_WITH_ACCOUNT", false),
+			TOTPIssuer:                 getEnv("AUTH_TOTP_ISSUER", "Simple Blog"),
+			LoginChallengeDuration:     getEnvAsDuration("AUTH_LOGIN_CHALLENGE_DURATION", 5*time.Minute),
+			PasswordMinLength:          getEnvAsInt("AUTH_PASSWORD_MIN_LENGTH", 8),
+		},
+		Mailer: mailer.Config{
+			Driver:       mailer.ParseDriver(getEnv("MAIL_DRIVER", "log")),
+			From:         getEnv("MAIL_FROM", "Blog API <no-reply@example.com>"),
+			FilePath:     getEnv("MAIL_FILE_PATH", "mail.log"),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		},
+		Login: lockout.Config{
+			Store: lockout.ParseStoreDriver(getEnv("LOGIN_ATTEMPT_STORE", "memory")),
+			Account: lockout.Limits{
+				BackoffAfter: getEnvAsInt("LOGIN_ACCOUNT_BACKOFF_AFTER", 3),
+				LockoutAfter: getEnvAsInt("LOGIN_ACCOUNT_LOCKOUT_AFTER", 10),
+			},
+			IP: lockout.Limits{
+				BackoffAfter: getEnvAsInt("LOGIN_IP_BACKOFF_AFTER", 20),
+				LockoutAfter: getEnvAsInt("LOGIN_IP_LOCKOUT_AFTER", 100),
+			},
+			BaseDelay:       getEnvAsDuration("LOGIN_BACKOFF_BASE_DELAY", time.Second),
+			LockoutDuration: getEnvAsDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+			FailureWindow:   getEnvAsDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+			CleanupInterval: getEnvAsDuration("LOGIN_ATTEMPT_CLEANUP_INTERVAL", time.Hour),
+		},
+		OIDC: OIDCConfig{
+			Providers:     getOIDCProviders(),
+			StateDuration: getEnvAsDuration("OIDC_STATE_DURATION", 10*time.Minute),
+		},
+		Password: password.Config{
+			Algorithm:         password.ParseAlgorithm(getEnv("PASSWORD_HASH_ALGORITHM", "argon2id")),
+			BcryptCost:        getEnvAsInt("PASSWORD_BCRYPT_COST", password.DefaultBcryptCost),
+			Argon2Memory:      uint32(getEnvAsInt("PASSWORD_ARGON2_MEMORY", password.DefaultArgon2Memory)),
+			Argon2Iterations:  uint32(getEnvAsInt("PASSWORD_ARGON2_ITERATIONS", password.DefaultArgon2Iterations)),
+			Argon2Parallelism: uint8(getEnvAsInt("PASSWORD_ARGON2_PARALLELISM", password.DefaultArgon2Parallelism)),
+		},
+		Post: PostConfig{
+			SchedulerInterval: getEnvAsDuration("POST_SCHEDULER_INTERVAL", time.Minute),
+			SearchLanguage:    getEnv("POST_SEARCH_LANGUAGE", "simple"),
 		},
 	}
 }
 
+// getOIDCProviders reads the providers listed in OIDC_PROVIDERS, e.g.
+// "google,gitlab", each configured by OIDC_<NAME>_* variables.
+func getOIDCProviders() []oidc.Config {
+	providers := []oidc.Config{}
+	for name := range strings.SplitSeq(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, oidc.Config{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/"+name+"/callback"),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "email profile")),
+		})
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -63,6 +206,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -72,6 +224,30 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// getEnvAsMap parses a comma separated list of key=value pairs, e.g.
+// "key-2025=/keys/2025.pem,key-2026=/keys/2026.pem".
+func getEnvAsMap(key string) map[string]string {
+	result := map[string]string{}
+	value := os.Getenv(key)
+	if value == "" {
+		return result
+	}
+
+	for pair := range strings.SplitSeq(value, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+		if k == "" || v == "" {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
 func loadEnvFile(filename string) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -0,0 +1,145 @@
+// Package diff compares two texts line by line with the Myers algorithm, the
+// one behind `git diff`, so the result is a shortest edit from one text to
+// the other.
+package diff
+
+import (
+	"slices"
+	"strings"
+)
+
+// Op tells whether a line is in both texts or only in one of them.
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpDelete Op = "delete"
+	OpInsert Op = "insert"
+)
+
+type Line struct {
+	Op   Op     `json:"op" enums:"equal,delete,insert" example:"insert"`
+	Text string `json:"text" example:"This is the updated content..."`
+}
+
+// MaxEdits bounds the work and memory of a comparison, which grow with the
+// square of the number of changed lines. Texts that differ in more lines are
+// reported as entirely replaced.
+const MaxEdits = 1000
+
+// Lines returns the lines of a and b in order, each marked as kept, deleted
+// from a or inserted from b.
+func Lines(a, b string) []Line {
+	x, y := split(a), split(b)
+
+	// Common leading and trailing lines are kept as they are, which is most
+	// of a text after a small edit
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix && x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]Line, 0, len(x)+len(y))
+	for _, text := range x[:prefix] {
+		lines = append(lines, Line{Op: OpEqual, Text: text})
+	}
+	lines = append(lines, myers(x[prefix:len(x)-suffix], y[prefix:len(y)-suffix])...)
+	for _, text := range x[len(x)-suffix:] {
+		lines = append(lines, Line{Op: OpEqual, Text: text})
+	}
+	return lines
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// myers finds the shortest edit script from x to y. For every number of
+// edits d it records the furthest point reached on each diagonal k = i - j,
+// then walks the recorded points back from the end.
+func myers(x, y []string) []Line {
+	n, m := len(x), len(y)
+	maxEdits := min(n+m, MaxEdits)
+
+	// furthest[k+offset] is the furthest i reached on diagonal k
+	offset := maxEdits + 1
+	furthest := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= maxEdits; d++ {
+		trace = append(trace, slices.Clone(furthest))
+		for k := -d; k <= d; k += 2 {
+			var i int
+			if k == -d || (k != d && furthest[k-1+offset] < furthest[k+1+offset]) {
+				i = furthest[k+1+offset] // insert from y
+			} else {
+				i = furthest[k-1+offset] + 1 // delete from x
+			}
+			j := i - k
+			for i < n && j < m && x[i] == y[j] {
+				i++
+				j++
+			}
+			furthest[k+offset] = i
+
+			if i >= n && j >= m {
+				return backtrack(x, y, trace, offset)
+			}
+		}
+	}
+
+	return replace(x, y)
+}
+
+func backtrack(x, y []string, trace [][]int, offset int) []Line {
+	var lines []Line
+	i, j := len(x), len(y)
+	for d := len(trace) - 1; d >= 0; d-- {
+		furthest := trace[d]
+		k := i - j
+
+		var prevK int
+		if k == -d || (k != d && furthest[k-1+offset] < furthest[k+1+offset]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevI := furthest[prevK+offset]
+		prevJ := prevI - prevK
+
+		for i > prevI && j > prevJ {
+			lines = append(lines, Line{Op: OpEqual, Text: x[i-1]})
+			i--
+			j--
+		}
+		if d > 0 {
+			if i == prevI {
+				lines = append(lines, Line{Op: OpInsert, Text: y[j-1]})
+			} else {
+				lines = append(lines, Line{Op: OpDelete, Text: x[i-1]})
+			}
+		}
+		i, j = prevI, prevJ
+	}
+
+	slices.Reverse(lines)
+	return lines
+}
+
+func replace(x, y []string) []Line {
+	lines := make([]Line, 0, len(x)+len(y))
+	for _, text := range x {
+		lines = append(lines, Line{Op: OpDelete, Text: text})
+	}
+	for _, text := range y {
+		lines = append(lines, Line{Op: OpInsert, Text: text})
+	}
+	return lines
+}
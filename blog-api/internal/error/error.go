@@ -1,9 +1,15 @@
 package error
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
 
-// AppError represents an application error with code and message
+// AppError represents an application error with code and message, and the
+// HTTP status it is answered with.
 type AppError struct {
+	Status  int    `json:"-"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Err     error  `json:"-"`
@@ -16,23 +22,58 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
 }
 
-func New(code, message string) *AppError {
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any AppError with the same code, so errors of different
+// features sharing a code, e.g. INVALID_INPUT, are interchangeable.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of the error with err as its cause, the copy still
+// matches e with errors.Is.
+func (e *AppError) Wrap(err error) *AppError {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+func New(status int, code, message string) *AppError {
 	return &AppError{
+		Status:  status,
 		Code:    code,
 		Message: message,
 	}
 }
 
+// GetCode returns the code of the first AppError in err's chain.
 func GetCode(err error) string {
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
 		return appErr.Code
 	}
 	return ""
 }
 
+// GetMessage returns the message of the first AppError in err's chain, or
+// err's own message when there is none.
 func GetMessage(err error) string {
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
 		return appErr.Message
 	}
 	return err.Error()
 }
+
+// GetStatus returns the HTTP status of the first AppError in err's chain,
+// anything else is an internal server error.
+func GetStatus(err error) int {
+	var appErr *AppError
+	if errors.As(err, &appErr) && appErr.Status != 0 {
+		return appErr.Status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,35 @@
+package jwks
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+type JWKSHandler struct {
+	keys *server.KeySet
+}
+
+func NewJWKSHandler(keys *server.KeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS godoc
+// @Summary      Get JSON Web Key Set
+// @Description  Returns the public keys used to verify access tokens issued by this service, so other services can verify them without the shared secret
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  server.JSONWebKeySet  "JSON Web Key Set"
+// @Router       /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	server.JSON(w, http.StatusOK, h.keys.JWKS())
+}
+
+// SetupRoutes configures the JWKS discovery route
+func (h *JWKSHandler) SetupRoutes(server *server.Server) {
+	// Well-known URI, no versioning
+	server.HandleFunc("GET /.well-known/jwks.json", h.GetJWKS)
+}
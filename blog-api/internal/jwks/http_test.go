@@ -0,0 +1,287 @@
+package jwks_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/jwks"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+const (
+	testIssuer   = "blog-api"
+	testAudience = "blog-api"
+)
+
+// writeKey writes a new private key of the given algorithm as a PEM file and
+// returns its path.
+func writeKey(t *testing.T, algorithm string) string {
+	t.Helper()
+
+	var key crypto.PrivateKey
+	switch algorithm {
+	case server.AlgorithmRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("Failed to generate RSA key: %v", err)
+		}
+		key = rsaKey
+	case server.AlgorithmEdDSA:
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("Failed to generate Ed25519 key: %v", err)
+		}
+		key = edKey
+	default:
+		t.Fatalf("Unsupported algorithm %s", algorithm)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return path
+}
+
+func loadKeySet(t *testing.T, config server.KeySetConfig) *server.KeySet {
+	t.Helper()
+
+	keys, err := server.LoadKeySet(config)
+	if err != nil {
+		t.Fatalf("Failed to load key set: %v", err)
+	}
+	return keys
+}
+
+// newTestServer serves the JWKS of keys and a protected route that accepts
+// the tokens they verify.
+func newTestServer(keys *server.KeySet) *server.Server {
+	srv := server.New(server.Config{Host: "localhost", Port: 8080})
+	srv.SetJWTMiddleware(server.NewJWTMiddleware(server.JWTConfig{
+		Keys:     keys,
+		Issuer:   testIssuer,
+		Audience: testAudience,
+	}))
+	jwks.NewJWKSHandler(keys).SetupRoutes(srv)
+	srv.HandleFuncWithAuth("GET /protected", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return srv
+}
+
+func generateToken(t *testing.T, keys *server.KeySet) string {
+	t.Helper()
+
+	token, err := server.NewJWTGenerator(server.JWTGeneratorConfig{
+		Keys:          keys,
+		TokenDuration: time.Minute,
+		Issuer:        testIssuer,
+		Audience:      testAudience,
+	}).GenerateToken(server.UserClaims{
+		UserID:   uuid.Must(uuid.NewV7()).String(),
+		Username: "keyuser",
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	return token
+}
+
+// tokenHeader returns the decoded JOSE header of a token.
+func tokenHeader(t *testing.T, token string) map[string]any {
+	t.Helper()
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+	if err != nil {
+		t.Fatalf("Failed to decode token header: %v", err)
+	}
+	var header map[string]any
+	if err := json.Unmarshal(data, &header); err != nil {
+		t.Fatalf("Failed to parse token header: %v", err)
+	}
+	return header
+}
+
+func callProtected(t *testing.T, srv *server.Server, token string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	srv.Mux().ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func getJWKS(t *testing.T, srv *server.Server) server.JSONWebKeySet {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+	srv.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var keySet server.JSONWebKeySet
+	if err := json.Unmarshal(rec.Body.Bytes(), &keySet); err != nil {
+		t.Fatalf("Failed to parse JWKS: %v", err)
+	}
+	return keySet
+}
+
+func TestJWKS_AsymmetricKeys(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		keyType   string
+		curve     string
+	}{
+		{algorithm: server.AlgorithmRS256, keyType: "RSA"},
+		{algorithm: server.AlgorithmEdDSA, keyType: "OKP", curve: "Ed25519"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			keys := loadKeySet(t, server.KeySetConfig{
+				Algorithm:      tt.algorithm,
+				SigningKeyID:   "key-1",
+				SigningKeyFile: writeKey(t, tt.algorithm),
+			})
+			srv := newTestServer(keys)
+
+			token := generateToken(t, keys)
+			header := tokenHeader(t, token)
+			if header["alg"] != tt.algorithm || header["kid"] != "key-1" {
+				t.Errorf("Expected alg '%s' and kid 'key-1', got %v", tt.algorithm, header)
+			}
+			if code := callProtected(t, srv, token); code != http.StatusNoContent {
+				t.Errorf("Expected status %d, got %d", http.StatusNoContent, code)
+			}
+
+			keySet := getJWKS(t, srv)
+			if len(keySet.Keys) != 1 {
+				t.Fatalf("Expected 1 key, got %+v", keySet.Keys)
+			}
+			key := keySet.Keys[0]
+			if key.KeyID != "key-1" || key.KeyType != tt.keyType || key.Algorithm != tt.algorithm || key.Use != "sig" || key.Curve != tt.curve {
+				t.Errorf("Unexpected key %+v", key)
+			}
+			if tt.keyType == "RSA" && (key.N == "" || key.E == "") {
+				t.Errorf("Expected the RSA modulus and exponent, got %+v", key)
+			}
+			if tt.keyType == "OKP" && key.X == "" {
+				t.Errorf("Expected the public key, got %+v", key)
+			}
+		})
+	}
+}
+
+func TestJWKS_HMACKeysNotPublished(t *testing.T) {
+	srv := newTestServer(server.NewHMACKeySet("test-secret"))
+
+	if keySet := getJWKS(t, srv); len(keySet.Keys) != 0 {
+		t.Errorf("Expected no published keys, got %+v", keySet.Keys)
+	}
+}
+
+func TestJWKS_KeyRotation(t *testing.T) {
+	oldKeyFile := writeKey(t, server.AlgorithmEdDSA)
+	oldKeys := loadKeySet(t, server.KeySetConfig{
+		Algorithm:      server.AlgorithmEdDSA,
+		SigningKeyID:   "key-1",
+		SigningKeyFile: oldKeyFile,
+	})
+	oldToken := generateToken(t, oldKeys)
+
+	// The new key signs while the retired one still verifies
+	newKeyFile := writeKey(t, server.AlgorithmRS256)
+	rotatedKeys := loadKeySet(t, server.KeySetConfig{
+		Algorithm:            server.AlgorithmRS256,
+		SigningKeyID:         "key-2",
+		SigningKeyFile:       newKeyFile,
+		VerificationKeyFiles: map[string]string{"key-1": oldKeyFile},
+	})
+	srv := newTestServer(rotatedKeys)
+
+	if code := callProtected(t, srv, oldToken); code != http.StatusNoContent {
+		t.Errorf("Expected a token of the retired key to verify, got %d", code)
+	}
+	newToken := generateToken(t, rotatedKeys)
+	if kid := tokenHeader(t, newToken)["kid"]; kid != "key-2" {
+		t.Errorf("Expected new tokens to be signed with 'key-2', got '%v'", kid)
+	}
+	if code := callProtected(t, srv, newToken); code != http.StatusNoContent {
+		t.Errorf("Expected a token of the new key to verify, got %d", code)
+	}
+
+	keyIDs := []string{}
+	for _, key := range getJWKS(t, srv).Keys {
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+	if expected := []string{"key-1", "key-2"}; !reflect.DeepEqual(keyIDs, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keyIDs)
+	}
+
+	// Once dropped from the verification keys, the retired key is rejected
+	srv = newTestServer(loadKeySet(t, server.KeySetConfig{
+		Algorithm:      server.AlgorithmRS256,
+		SigningKeyID:   "key-2",
+		SigningKeyFile: newKeyFile,
+	}))
+	if code := callProtected(t, srv, oldToken); code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, code)
+	}
+}
+
+func TestJWKS_IssuerAndAudience(t *testing.T) {
+	keys := server.NewHMACKeySet("test-secret")
+	srv := newTestServer(keys)
+
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		expected int
+	}{
+		{name: "Matching", issuer: testIssuer, audience: testAudience, expected: http.StatusNoContent},
+		{name: "Other issuer", issuer: "other-api", audience: testAudience, expected: http.StatusUnauthorized},
+		{name: "Other audience", issuer: testIssuer, audience: "other-api", expected: http.StatusUnauthorized},
+		{name: "Missing", expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := server.NewJWTGenerator(server.JWTGeneratorConfig{
+				Keys:          keys,
+				TokenDuration: time.Minute,
+				Issuer:        tt.issuer,
+				Audience:      tt.audience,
+			}).GenerateToken(server.UserClaims{UserID: uuid.Must(uuid.NewV7()).String()})
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+			if code := callProtected(t, srv, token); code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, code)
+			}
+		})
+	}
+}
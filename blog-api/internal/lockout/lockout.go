@@ -0,0 +1,233 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store keeps the failed attempt counters. The memory implementation only
+// works for a single instance, the Postgres one shares the counters between
+// instances.
+type Store interface {
+	// RecordFailure counts a failed attempt for key and returns the number of
+	// failures so far. The count restarts when the previous failure is older
+	// than window.
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	Reset(ctx context.Context, key string) error
+	// DeleteStale removes keys that are not locked and whose last failure is
+	// older than window.
+	DeleteStale(ctx context.Context, window time.Duration) (int64, error)
+}
+
+type StoreDriver int
+
+const (
+	StoreMemory StoreDriver = iota
+	StorePostgres
+)
+
+func (d StoreDriver) String() string {
+	switch d {
+	case StorePostgres:
+		return "postgres"
+	default:
+		return "memory"
+	}
+}
+
+func ParseStoreDriver(s string) StoreDriver {
+	switch s {
+	case "postgres":
+		return StorePostgres
+	default:
+		return StoreMemory
+	}
+}
+
+func NewStore(driver StoreDriver, db *pgxpool.Pool) (Store, error) {
+	switch driver {
+	case StorePostgres:
+		if db == nil {
+			return nil, fmt.Errorf("database pool is required")
+		}
+		return NewPostgresStore(db), nil
+	default:
+		return NewMemoryStore(), nil
+	}
+}
+
+// Limits configures when the attempts of one key get throttled. A zero
+// threshold turns that step off.
+type Limits struct {
+	// BackoffAfter is the number of failures after which every further
+	// failure blocks the key for an exponentially growing delay.
+	BackoffAfter int
+	// LockoutAfter is the number of failures after which the key is locked
+	// for the lockout duration.
+	LockoutAfter int
+}
+
+type Config struct {
+	Store   StoreDriver
+	Account Limits
+	IP      Limits
+	// BaseDelay is the first backoff delay, it doubles with every failure up
+	// to LockoutDuration.
+	BaseDelay       time.Duration
+	LockoutDuration time.Duration
+	// FailureWindow is how long a failure is remembered.
+	FailureWindow   time.Duration
+	CleanupInterval time.Duration
+}
+
+// LockedError is returned while a login is blocked.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed attempts, retry after %s", e.RetryAfter)
+}
+
+// Guard tracks failed logins per account and per client IP.
+type Guard struct {
+	config Config
+	store  Store
+}
+
+func New(config Config, store Store) *Guard {
+	return &Guard{
+		config: config,
+		store:  store,
+	}
+}
+
+// Allow returns a *LockedError when either the account or the IP is
+// currently blocked.
+func (g *Guard) Allow(ctx context.Context, account, ip string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, key := range g.keys(account, ip) {
+		until, err := g.store.LockedUntil(ctx, key)
+		if err != nil {
+			return err
+		}
+		if wait := until.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login for the account and the IP, and blocks
+// whichever crossed its limits.
+func (g *Guard) RecordFailure(ctx context.Context, account, ip string) error {
+	if err := g.recordFailure(ctx, accountKey(account), g.config.Account); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return g.recordFailure(ctx, ipKey(ip), g.config.IP)
+}
+
+// RecordSuccess clears the account's failures. The IP's failures are kept,
+// otherwise one valid account would let an attacker keep guessing others.
+func (g *Guard) RecordSuccess(ctx context.Context, account string) error {
+	return g.store.Reset(ctx, accountKey(account))
+}
+
+// RunCleanup periodically removes counters that are no longer relevant. It
+// blocks until ctx is cancelled.
+func (g *Guard) RunCleanup(ctx context.Context) {
+	if g.config.CleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(g.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := g.store.DeleteStale(ctx, g.config.FailureWindow)
+			if err != nil {
+				slog.Error("Failed to delete stale login attempts",
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+
+			slog.Debug("Cleaned up stale login attempts",
+				slog.Int64("deleted", deleted),
+			)
+		}
+	}
+}
+
+func (g *Guard) recordFailure(ctx context.Context, key string, limits Limits) error {
+	if limits.BackoffAfter <= 0 && limits.LockoutAfter <= 0 {
+		return nil
+	}
+
+	failures, err := g.store.RecordFailure(ctx, key, g.config.FailureWindow)
+	if err != nil {
+		return err
+	}
+
+	var delay time.Duration
+	switch {
+	case limits.LockoutAfter > 0 && failures >= limits.LockoutAfter:
+		delay = g.config.LockoutDuration
+		slog.WarnContext(ctx, "Login locked out",
+			slog.String("key", key),
+			slog.Int("failures", failures),
+			slog.Duration("duration", delay),
+		)
+	case limits.BackoffAfter > 0 && failures >= limits.BackoffAfter:
+		delay = g.backoff(failures - limits.BackoffAfter)
+	default:
+		return nil
+	}
+
+	return g.store.Lock(ctx, key, time.Now().Add(delay))
+}
+
+// backoff returns BaseDelay doubled step times, capped at LockoutDuration.
+func (g *Guard) backoff(step int) time.Duration {
+	delay := float64(g.config.BaseDelay) * math.Pow(2, float64(step))
+	if delay > float64(g.config.LockoutDuration) {
+		return g.config.LockoutDuration
+	}
+	return time.Duration(delay)
+}
+
+func (g *Guard) keys(account, ip string) []string {
+	keys := []string{accountKey(account)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	return keys
+}
+
+func accountKey(account string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(account))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
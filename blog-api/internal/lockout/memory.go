@@ -0,0 +1,81 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	failures     int
+	lastFailedAt time.Time
+	lockedUntil  time.Time
+}
+
+// MemoryStore keeps the counters in the process memory, so they reset on
+// restart and are not shared between instances.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (s *MemoryStore) RecordFailure(_ context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry := s.entries[key]
+	if now.Sub(entry.lastFailedAt) > window {
+		entry.failures = 0
+	}
+	entry.failures++
+	entry.lastFailedAt = now
+	s.entries[key] = entry
+	return entry.failures, nil
+}
+
+func (s *MemoryStore) Lock(_ context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.entries[key]
+	entry.lockedUntil = until
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryStore) LockedUntil(_ context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.entries[key].lockedUntil, nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *MemoryStore) DeleteStale(_ context.Context, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var deleted int64
+	for key, entry := range s.entries {
+		if now.Sub(entry.lastFailedAt) > window && now.After(entry.lockedUntil) {
+			delete(s.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
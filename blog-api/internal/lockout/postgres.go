@@ -0,0 +1,93 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore keeps the counters in the login_attempts table so every
+// instance sees the same failures.
+type PostgresStore struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresStore(db *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) RecordFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	query := `
+		INSERT INTO login_attempts (
+			key,
+			failures,
+			last_failed_at
+		)
+		VALUES ($1, 1, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE
+				WHEN login_attempts.last_failed_at < NOW() - $2::INTERVAL THEN 1
+				ELSE login_attempts.failures + 1
+			END,
+			last_failed_at = NOW()
+		RETURNING failures
+	`
+	var failures int
+	err := s.db.QueryRow(ctx, query, key, window).Scan(&failures)
+	return failures, err
+}
+
+func (s *PostgresStore) Lock(ctx context.Context, key string, until time.Time) error {
+	query := `
+		UPDATE login_attempts SET
+			locked_until = $1
+		WHERE
+			key = $2
+	`
+	_, err := s.db.Exec(ctx, query, until, key)
+	return err
+}
+
+func (s *PostgresStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	query := `
+		SELECT
+			locked_until
+		FROM login_attempts
+		WHERE
+			key = $1
+			AND locked_until > NOW()
+	`
+	var until time.Time
+	err := s.db.QueryRow(ctx, query, key).Scan(&until)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return until, err
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM login_attempts
+		WHERE
+			key = $1
+	`
+	_, err := s.db.Exec(ctx, query, key)
+	return err
+}
+
+func (s *PostgresStore) DeleteStale(ctx context.Context, window time.Duration) (int64, error) {
+	query := `
+		DELETE FROM login_attempts
+		WHERE
+			last_failed_at < NOW() - $1::INTERVAL
+			AND (locked_until IS NULL OR locked_until < NOW())
+	`
+	tag, err := s.db.Exec(ctx, query, window)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileMailer appends every email to a file, in the same format that would be
+// sent over SMTP.
+type FileMailer struct {
+	mu   sync.Mutex
+	from string
+	path string
+}
+
+func NewFileMailer(from, path string) *FileMailer {
+	return &FileMailer{
+		from: from,
+		path: path,
+	}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail file: %w", err)
+	}
+
+	content := append(buildMessage(m.from, msg), []byte("\r\n\r\n")...)
+	if _, err := file.Write(content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,77 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails. The SMTP implementation is meant for production,
+// the log and file implementations make it possible to follow the flows that
+// send emails on a local machine.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Driver int
+
+const (
+	DriverLog Driver = iota
+	DriverFile
+	DriverSMTP
+)
+
+func (d Driver) String() string {
+	switch d {
+	case DriverFile:
+		return "file"
+	case DriverSMTP:
+		return "smtp"
+	default:
+		return "log"
+	}
+}
+
+func ParseDriver(s string) Driver {
+	switch s {
+	case "file":
+		return DriverFile
+	case "smtp":
+		return DriverSMTP
+	default:
+		return DriverLog
+	}
+}
+
+type Config struct {
+	Driver       Driver
+	From         string
+	FilePath     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+}
+
+func New(config Config) (Mailer, error) {
+	switch config.Driver {
+	case DriverSMTP:
+		if config.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP host is required")
+		}
+		return NewSMTPMailer(config), nil
+	case DriverFile:
+		if config.FilePath == "" {
+			return nil, fmt.Errorf("mail file path is required")
+		}
+		return NewFileMailer(config.From, config.FilePath), nil
+	default:
+		return NewLogMailer(config.From), nil
+	}
+}
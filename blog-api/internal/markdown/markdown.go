@@ -0,0 +1,102 @@
+// Package markdown renders post content, written in CommonMark with the
+// GitHub extensions (tables, strikethrough, autolinks and task lists), into
+// HTML that is safe to embed in a page. HTML written in the content is kept
+// but sanitized, which strips scripts, event handlers and `javascript:` URLs.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// Heading is an entry of the table of contents, ID is the id of the heading
+// in the rendered HTML to link to it with `#id`.
+type Heading struct {
+	Level int    `json:"level" example:"2"`
+	Text  string `json:"text" example:"Getting started"`
+	ID    string `json:"id" example:"getting-started"`
+}
+
+// Raw HTML is rendered as is, the policy sanitizes it afterwards
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+var (
+	policy   = newPolicy()
+	checkbox = regexp.MustCompile(`^checkbox$`)
+)
+
+// newPolicy allows the HTML of user generated content, which includes the
+// ids of headings, plus the checkboxes of task lists.
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("type").Matching(checkbox).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render returns the sanitized HTML of source.
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return policy.Sanitize(buf.String()), nil
+}
+
+// TOC returns the headings of source in order, with the same IDs as in the
+// HTML of Render.
+func TOC(source string) []Heading {
+	src := []byte(source)
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	headings := []Heading{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		id, _ := heading.AttributeString("id")
+		idBytes, _ := id.([]byte)
+		headings = append(headings, Heading{
+			Level: heading.Level,
+			Text:  strings.TrimSpace(plainText(heading, src)),
+			ID:    string(idBytes),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	return headings
+}
+
+// plainText returns the text of an inline node without its formatting, raw
+// HTML is left out.
+func plainText(n ast.Node, source []byte) string {
+	var sb strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			sb.Write(c.Segment.Value(source))
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(c.Value)
+		case *ast.RawHTML:
+		default:
+			sb.WriteString(plainText(c, source))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package markdown_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/markdown"
+)
+
+func TestRender_Markdown(t *testing.T) {
+	html, err := markdown.Render("# Guide\n\nSome **bold** text\n\n## Getting *started*\n\n- [x] done\n")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`<h1 id="guide">Guide</h1>`,
+		`<strong>bold</strong>`,
+		`<h2 id="getting-started">Getting <em>started</em></h2>`,
+		`<input checked="" disabled="" type="checkbox"> done`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q, got %q", want, html)
+		}
+	}
+}
+
+func TestRender_Sanitizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		removed string
+		kept    string
+	}{
+		{
+			name:    "Script",
+			source:  "Hello <script>alert('xss')</script>",
+			removed: "<script",
+			kept:    "Hello",
+		},
+		{
+			name:    "JavaScript link",
+			source:  "[click](javascript:alert('xss'))",
+			removed: "javascript:",
+			kept:    "click",
+		},
+		{
+			name:    "JavaScript link in HTML",
+			source:  `<a href="javascript:alert('xss')">click</a>`,
+			removed: "javascript:",
+			kept:    "click",
+		},
+		{
+			name:    "Event handler",
+			source:  `link <img src="cat.png" onerror="alert('xss')">`,
+			removed: "onerror",
+			kept:    `<img src="cat.png">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := markdown.Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if strings.Contains(html, tt.removed) {
+				t.Errorf("Expected %q to be removed, got %q", tt.removed, html)
+			}
+			if !strings.Contains(html, tt.kept) {
+				t.Errorf("Expected %q to be kept, got %q", tt.kept, html)
+			}
+		})
+	}
+}
+
+func TestTOC(t *testing.T) {
+	source := "# Guide\n\nIntro\n\n## Getting *started*\n\n### Install it\n\n## Guide\n"
+
+	expected := []markdown.Heading{
+		{Level: 1, Text: "Guide", ID: "guide"},
+		{Level: 2, Text: "Getting started", ID: "getting-started"},
+		{Level: 3, Text: "Install it", ID: "install-it"},
+		{Level: 2, Text: "Guide", ID: "guide-1"},
+	}
+	toc := markdown.TOC(source)
+	if !slices.Equal(toc, expected) {
+		t.Errorf("Expected TOC %v, got %v", expected, toc)
+	}
+
+	// The IDs link to the headings of the rendered HTML
+	html, err := markdown.Render(source)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, heading := range toc {
+		if !strings.Contains(html, `id="`+heading.ID+`"`) {
+			t.Errorf("Expected HTML to have a heading with id %q, got %q", heading.ID, html)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package normalize folds usernames and emails into the form they are
+// compared and kept unique in, so `John@Example.com` and `john@example.com`
+// are the same account. The form the user typed is kept for display.
+package normalize
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Identifier returns the NFKC normalized, lowercased and trimmed form of a
+// username or email. NFKC folds compatibility characters, e.g. fullwidth
+// letters, into the letters they look like, and runs first so that the
+// whitespace it produces is trimmed too.
+//
+// The migration that added the normalized columns backfilled the existing
+// users with the same steps in SQL, keep both in sync.
+func Identifier(s string) string {
+	return strings.TrimSpace(strings.ToLower(norm.NFKC.String(s)))
+}
@@ -0,0 +1,204 @@
+// Package pagination splits listings into pages, either by page number or by
+// keyset. A keyset page continues from an opaque cursor holding the sort key
+// and ID of the row it starts after, so deep pages stay as fast as the first
+// one and rows inserted meanwhile do not shift the pages.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+var ErrInvalidCursor = appError.New(http.StatusBadRequest, "INVALID_CURSOR", "Invalid cursor")
+
+// Cursor is the position of a row in a sorted listing, with the ID breaking
+// ties between rows with the same sort key.
+type Cursor struct {
+	// Sort is the order of the listing the cursor was made in, a cursor only
+	// continues a listing in the same order.
+	Sort string `json:"s,omitempty"`
+	// Key is the sort key of the row as text, e.g. its creation time.
+	Key string    `json:"k"`
+	ID  uuid.UUID `json:"id"`
+	// Before selects the rows before the cursor instead of the ones after.
+	Before bool `json:"b,omitempty"`
+}
+
+func (c Cursor) IsZero() bool {
+	return c.ID == uuid.Nil
+}
+
+// String encodes the cursor, clients should not rely on what is inside.
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// TimeKey is the key of rows sorted by a time.
+func TimeKey(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// Time returns the key of a cursor made with TimeKey.
+func (c Cursor) Time() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+func ParseCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Request is the page of a listing a client asks for.
+type Request struct {
+	Page     int
+	PageSize int
+	// Cursor takes precedence over Page when set.
+	Cursor Cursor
+	// IncludeTotal asks for the number of rows of the whole listing, which
+	// takes counting all of them.
+	IncludeTotal bool
+}
+
+// FromRequest reads the `page`, `page_size`, `cursor` and `include_total`
+// query params. Invalid numbers fall back to the first page of 10 rows, an
+// invalid cursor is an error. The result still has to be normalized.
+func FromRequest(r *http.Request) (Request, error) {
+	query := r.URL.Query()
+	p := Request{Page: 1, PageSize: DefaultPageSize}
+
+	if page, err := strconv.Atoi(query.Get("page")); err == nil && page > 0 {
+		p.Page = page
+	}
+	if pageSize, err := strconv.Atoi(query.Get("page_size")); err == nil && pageSize > 0 {
+		p.PageSize = pageSize
+	}
+	p.IncludeTotal, _ = strconv.ParseBool(query.Get("include_total"))
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		c, err := ParseCursor(cursor)
+		if err != nil {
+			return Request{}, err
+		}
+		p.Cursor = c
+	}
+	return p, nil
+}
+
+// Normalize falls back to the first page of 10 rows for out of range
+// values.
+func (p *Request) Normalize() {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 || p.PageSize > MaxPageSize {
+		p.PageSize = DefaultPageSize
+	}
+}
+
+// CheckSort returns ErrInvalidCursor when the cursor was made in a listing
+// of another order.
+func (p Request) CheckSort(sort string) error {
+	if !p.Cursor.IsZero() && p.Cursor.Sort != sort {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// Limit is one more than the page size, the extra row tells whether there is
+// a next page.
+func (p Request) Limit() int {
+	return p.PageSize + 1
+}
+
+// Offset is the number of rows to skip, keyset pages skip none.
+func (p Request) Offset() int {
+	if !p.Cursor.IsZero() {
+		return 0
+	}
+	return (p.Page - 1) * p.PageSize
+}
+
+// Page is a page of rows with the cursors of the pages around it, which are
+// empty when there is no such page.
+type Page[T any] struct {
+	Rows       []T
+	NextCursor string
+	PrevCursor string
+}
+
+// Paginate cuts the rows fetched with Limit down to the page. Rows before a
+// cursor are fetched in reverse, from the cursor on, and are put back in
+// order.
+func Paginate[T any](p Request, rows []T, cursor func(T) Cursor) Page[T] {
+	more := len(rows) > p.PageSize
+	if more {
+		rows = rows[:p.PageSize]
+	}
+
+	hasNext, hasPrev := more, !p.Cursor.IsZero() || p.Page > 1
+	if p.Cursor.Before {
+		slices.Reverse(rows)
+		hasNext, hasPrev = true, more
+	}
+
+	page := Page[T]{Rows: rows}
+	if len(rows) == 0 {
+		return page
+	}
+	if hasNext {
+		page.NextCursor = cursor(rows[len(rows)-1]).String()
+	}
+	if hasPrev {
+		prev := cursor(rows[0])
+		prev.Before = true
+		page.PrevCursor = prev.String()
+	}
+	return page
+}
+
+// SetLinks adds the RFC 8288 Link header pointing to the next and previous
+// pages, keeping the other query params of the request.
+func SetLinks(w http.ResponseWriter, r *http.Request, nextCursor, prevCursor string) {
+	var links []string
+	link := func(cursor, rel string) {
+		if cursor == "" {
+			return
+		}
+		query := r.URL.Query()
+		query.Del("page")
+		query.Set("cursor", cursor)
+		links = append(links, "<"+r.URL.Path+"?"+query.Encode()+`>; rel="`+rel+`"`)
+	}
+	link(nextCursor, "next")
+	link(prevCursor, "prev")
+
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,29 @@
+package password
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// commonPasswordsFile lists the most frequently used passwords found in
+// public breaches, one per line, in lowercase.
+//
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = func() map[string]struct{} {
+	passwords := map[string]struct{}{}
+	for line := range strings.Lines(commonPasswordsFile) {
+		if line = strings.TrimSpace(line); line != "" {
+			passwords[line] = struct{}{}
+		}
+	}
+	return passwords
+}()
+
+// IsCommon reports whether the password, ignoring case, is on the bundled
+// list of common passwords.
+func IsCommon(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
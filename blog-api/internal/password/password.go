@@ -0,0 +1,189 @@
+// Package password hashes passwords and checks them against a list of common
+// passwords.
+//
+// Hashes are self-describing, the algorithm and its parameters are encoded in
+// the hash itself: bcrypt hashes start with `$2a$<cost>$` and argon2id hashes
+// use the PHC string format `$argon2id$v=19$m=<KiB>,t=<iterations>,p=<lanes>$<salt>$<key>`.
+// That lets Verify tell when a stored hash was made with older settings and
+// should be replaced.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Algorithm int
+
+const (
+	Argon2id Algorithm = iota
+	Bcrypt
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case Bcrypt:
+		return "bcrypt"
+	default:
+		return "argon2id"
+	}
+}
+
+func ParseAlgorithm(s string) Algorithm {
+	switch s {
+	case "bcrypt":
+		return Bcrypt
+	default:
+		return Argon2id
+	}
+}
+
+// Config selects how new hashes are made. Zero values fall back to the
+// defaults, the argon2id ones are the minimum OWASP recommends.
+type Config struct {
+	Algorithm  Algorithm
+	BcryptCost int
+	// Argon2Memory is in KiB.
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+const (
+	DefaultBcryptCost        = 12
+	DefaultArgon2Memory      = 19 * 1024
+	DefaultArgon2Iterations  = 2
+	DefaultArgon2Parallelism = 1
+
+	argon2SaltSize = 16
+	argon2KeySize  = 32
+)
+
+// MaxLength is the most bytes a password may have, bcrypt rejects longer
+// ones. It applies whatever the algorithm, so switching to bcrypt never
+// locks anyone out.
+const MaxLength = 72
+
+var errMalformedHash = errors.New("malformed password hash")
+
+type Hasher struct {
+	config Config
+}
+
+func NewHasher(config Config) *Hasher {
+	if config.BcryptCost == 0 {
+		config.BcryptCost = DefaultBcryptCost
+	}
+	if config.Argon2Memory == 0 {
+		config.Argon2Memory = DefaultArgon2Memory
+	}
+	if config.Argon2Iterations == 0 {
+		config.Argon2Iterations = DefaultArgon2Iterations
+	}
+	if config.Argon2Parallelism == 0 {
+		config.Argon2Parallelism = DefaultArgon2Parallelism
+	}
+	return &Hasher{config: config}
+}
+
+// Hash hashes the password with the configured algorithm.
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.config.Algorithm == Bcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	}
+
+	salt := make([]byte, argon2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	params := argon2Params{
+		memory:      h.config.Argon2Memory,
+		iterations:  h.config.Argon2Iterations,
+		parallelism: h.config.Argon2Parallelism,
+	}
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeySize)
+	return params.encode(salt, key), nil
+}
+
+// Verify reports whether the password matches the hash, and if so whether
+// the hash was made with another algorithm or weaker parameters than the
+// configured ones and should be replaced with a new Hash of the password.
+func (h *Hasher) Verify(hash, password string) (match bool, rehash bool) {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		params, salt, key, err := decodeArgon2(hash)
+		if err != nil {
+			return false, false
+		}
+		computed := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(computed, key) != 1 {
+			return false, false
+		}
+		return true, h.config.Algorithm != Argon2id ||
+			params.memory < h.config.Argon2Memory ||
+			params.iterations < h.config.Argon2Iterations ||
+			params.parallelism < h.config.Argon2Parallelism
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true, true
+	}
+	return true, h.config.Algorithm != Bcrypt || cost < h.config.BcryptCost
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func (p argon2Params) encode(salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		p.memory, p.iterations, p.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeArgon2(hash string) (argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,82 @@
+package policy
+
+import "github.com/google/uuid"
+
+// Role is the set of permissions granted to a user. Every role includes the
+// permissions of the roles below it.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// AtLeast reports whether r grants every permission of required. Unknown
+// roles never do.
+func (r Role) AtLeast(required Role) bool {
+	rank, ok := roleRanks[r]
+	if !ok {
+		return false
+	}
+	return rank >= roleRanks[required]
+}
+
+// Scope limits what a personal access token can do on behalf of its owner.
+type Scope string
+
+const (
+	ScopePostsWrite    Scope = "posts:write"
+	ScopeCommentsWrite Scope = "comments:write"
+)
+
+var scopes = map[Scope]bool{
+	ScopePostsWrite:    true,
+	ScopeCommentsWrite: true,
+}
+
+// Valid reports whether s is one of the known scopes.
+func (s Scope) Valid() bool {
+	return scopes[s]
+}
+
+// Actor is the user performing an action.
+type Actor struct {
+	UserID uuid.UUID
+	Role   Role
+}
+
+// CanUpdate reports whether the actor may edit content owned by ownerID.
+// Only the author edits their own content.
+func CanUpdate(actor Actor, ownerID uuid.UUID) bool {
+	return actor.UserID == ownerID
+}
+
+// CanDelete reports whether the actor may remove content owned by ownerID.
+// Moderators and admins can remove anyone's content.
+func CanDelete(actor Actor, ownerID uuid.UUID) bool {
+	return actor.UserID == ownerID || actor.Role.AtLeast(RoleModerator)
+}
+
+// CanViewRevisions reports whether the actor may read the earlier versions
+// of content owned by ownerID. Moderators can, to review what was changed.
+func CanViewRevisions(actor Actor, ownerID uuid.UUID) bool {
+	return actor.UserID == ownerID || actor.Role.AtLeast(RoleModerator)
+}
+
+// CanManageRoles reports whether the actor may change users' roles.
+func CanManageRoles(actor Actor) bool {
+	return actor.Role.AtLeast(RoleAdmin)
+}
@@ -2,25 +2,56 @@ package post
 
 import (
 	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/diff"
+	"github.com/fikryfahrezy/forward/blog-api/internal/markdown"
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
+)
+
+// Status is where a post is in its lifecycle, only published posts are
+// visible to users other than the author.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusScheduled Status = "scheduled"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
 )
 
 type Post struct {
-	ID        uuid.UUID    `json:"id"`
-	Title     string       `json:"title"`
-	Slug      string       `json:"slug"`
-	Content   string       `json:"content"`
-	AuthorID  uuid.UUID    `json:"author_id"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
-	DeletedAt sql.NullTime `json:"-"`
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+	Slug  string    `json:"slug"`
+	// Content is Markdown, ContentHTML is its rendered HTML, which is empty
+	// for posts not saved again since it was added.
+	Content     string    `json:"content"`
+	ContentHTML string    `json:"-"`
+	AuthorID    uuid.UUID `json:"author_id"`
+	Status      Status    `json:"status"`
+	// PublishedAt is when the post was or, if scheduled, will be published.
+	PublishedAt *time.Time   `json:"published_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"-"`
+	// SearchLanguage is the text search configuration the post is indexed
+	// with when saved, it is not read back.
+	SearchLanguage string `json:"-"`
 }
 
 type PostWithAuthor struct {
 	Post
 	AuthorUsername string `json:"author_username"`
+	CommentCount   int    `json:"comment_count"`
 }
 
 type PostID struct {
@@ -30,43 +61,282 @@ type PostID struct {
 type CreatePostRequest struct {
 	Title   string `json:"title" example:"My First Blog Post"`
 	Content string `json:"content" example:"This is the content of my first blog post..."`
+	// Status defaults to published.
+	Status Status `json:"status,omitempty" enums:"draft,scheduled,published" example:"draft"`
+	// PublishAt is required for scheduled posts.
+	PublishAt *time.Time `json:"publish_at,omitempty" example:"2027-01-01T09:00:00Z"`
+	Tags      []string   `json:"tags,omitempty" example:"Go,Databases"`
+}
+
+// maxTitleLength is the length of the posts.title column.
+const maxTitleLength = 200
+
+const (
+	// maxTagLength is the length of the tags.slug and tags.name columns.
+	maxTagLength = 50
+	maxTags      = 10
+)
+
+func validatePost(v *validation.Validator, title, content string, tags []string) {
+	v.Required("title", title)
+	v.MaxLength("title", title, maxTitleLength)
+	v.Required("content", content)
+
+	v.Check(len(tags) <= maxTags, "tags", validation.CodeInvalidFormat, fmt.Sprintf("must have at most %d tags", maxTags))
+	for _, name := range tags {
+		slug := TagSlug(name)
+		v.Check(slug != "", "tags", validation.CodeInvalidFormat, fmt.Sprintf("%q must contain a letter or digit", name))
+		// Normalizing can make the slug longer than the name, e.g. `ﬁ` is `fi`
+		tooLong := utf8.RuneCountInString(strings.TrimSpace(name)) > maxTagLength || utf8.RuneCountInString(slug) > maxTagLength
+		v.Check(!tooLong, "tags", validation.CodeTooLong, fmt.Sprintf("%q must be at most %d characters", name, maxTagLength))
+	}
 }
 
 func (r CreatePostRequest) Validate() error {
-	if r.Title == "" || r.Content == "" {
-		return ErrInvalidInput
+	v := validation.Validator{}
+	validatePost(&v, r.Title, r.Content, r.Tags)
+	switch r.Status {
+	case "", StatusDraft, StatusPublished:
+		v.Check(r.PublishAt == nil, "publish_at", validation.CodeInvalidFormat, "is only allowed for scheduled posts")
+	case StatusScheduled:
+		validatePublishAt(&v, r.PublishAt, true)
+	default:
+		v.Check(false, "status", validation.CodeInvalidFormat, "must be one of draft, scheduled or published")
+	}
+	return v.Err()
+}
+
+// validatePublishAt checks the time a post is scheduled for.
+func validatePublishAt(v *validation.Validator, publishAt *time.Time, required bool) {
+	if publishAt == nil {
+		v.Check(!required, "publish_at", validation.CodeRequired, "is required")
+		return
 	}
-	return nil
+	v.Check(publishAt.After(time.Now()), "publish_at", validation.CodeInvalidFormat, "must be in the future")
 }
 
 type UpdatePostRequest struct {
 	Title   string `json:"title" example:"Updated Blog Post Title"`
 	Content string `json:"content" example:"This is the updated content..."`
+	// Tags replace the tags of the post, an empty list removes them and
+	// leaving it out keeps them.
+	Tags []string `json:"tags" example:"Go,Databases"`
 }
 
 func (r UpdatePostRequest) Validate() error {
-	if r.Title == "" || r.Content == "" {
-		return ErrInvalidInput
+	v := validation.Validator{}
+	validatePost(&v, r.Title, r.Content, r.Tags)
+	return v.Err()
+}
+
+// PublishPostRequest publishes a post right away, or schedules it when
+// PublishAt is set.
+type PublishPostRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty" example:"2027-01-01T09:00:00Z"`
+}
+
+func (r PublishPostRequest) Validate() error {
+	v := validation.Validator{}
+	validatePublishAt(&v, r.PublishAt, false)
+	return v.Err()
+}
+
+// Format is the form the content of a post is returned in.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// ParseFormat reads the `format` query param, which defaults to Markdown.
+func ParseFormat(format string) (Format, error) {
+	v := validation.Validator{}
+	switch Format(format) {
+	case "":
+		return FormatMarkdown, nil
+	case FormatMarkdown, FormatHTML:
+	default:
+		v.Check(false, "format", validation.CodeInvalidFormat, "must be markdown or html")
 	}
-	return nil
+	return Format(format), v.Err()
+}
+
+type PostStatusResponse struct {
+	ID          string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status      Status     `json:"status" example:"scheduled"`
+	PublishedAt *time.Time `json:"published_at" example:"2027-01-01T09:00:00Z"`
 }
 
 type PostItem struct {
-	ID             uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Title          string    `json:"title" example:"My First Blog Post"`
-	Slug           string    `json:"slug" example:"my-first-blog-post-a1b2c3d4"`
-	Content        string    `json:"content" example:"This is the content of my first blog post..."`
-	AuthorID       uuid.UUID `json:"author_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	AuthorUsername string    `json:"author_username" example:"johndoe"`
-	CreatedAt      time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt      time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID    uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title string    `json:"title" example:"My First Blog Post"`
+	Slug  string    `json:"slug" example:"my-first-blog-post-a1b2c3d4"`
+	// Content is the Markdown of the post, left out when a post is asked for
+	// as HTML.
+	Content string `json:"content,omitempty" example:"This is the content of my *first* blog post..."`
+	// ContentHTML and TOC are only returned for a post asked for as HTML.
+	ContentHTML    string             `json:"content_html,omitempty" example:"<p>This is the content of my <em>first</em> blog post...</p>"`
+	TOC            []markdown.Heading `json:"toc,omitempty"`
+	AuthorID       uuid.UUID          `json:"author_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	AuthorUsername string             `json:"author_username" example:"johndoe"`
+	// Status is always published for posts of other authors.
+	Status      Status     `json:"status" example:"published"`
+	PublishedAt *time.Time `json:"published_at" example:"2024-01-01T00:00:00Z"`
+	Tags        []Tag      `json:"tags"`
+	// CommentCount counts the comments that are not deleted.
+	CommentCount int       `json:"comment_count" example:"5"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// ListFilter narrows down the posts of a listing, zero fields do not filter.
+type ListFilter struct {
+	AuthorID uuid.UUID
+	// Author is the username of the author.
+	Author string
+	// ViewerID also lists the unpublished posts of the user viewing the
+	// listing, other users only see published posts.
+	ViewerID uuid.UUID
+	// Tag is the slug of a tag the posts have.
+	Tag string
+	// The ranges include their start and exclude their end.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time
+	// Sort orders the posts, from the newest when zero.
+	Sort ListSort
+}
+
+type SortField string
+
+const (
+	SortCreatedAt    SortField = "created_at"
+	SortUpdatedAt    SortField = "updated_at"
+	SortTitle        SortField = "title"
+	SortCommentCount SortField = "comment_count"
+)
+
+type ListSort struct {
+	Field SortField
+	Asc   bool
+}
+
+func (s ListSort) String() string {
+	if s.Field == "" {
+		s.Field = SortCreatedAt
+	}
+	if s.Asc {
+		return string(s.Field) + ".asc"
+	}
+	return string(s.Field) + ".desc"
+}
+
+// CursorKey returns the sort key of p for its cursor.
+func (s ListSort) CursorKey(p PostWithAuthor) string {
+	switch s.Field {
+	case SortUpdatedAt:
+		return pagination.TimeKey(p.UpdatedAt)
+	case SortTitle:
+		return p.Title
+	case SortCommentCount:
+		return strconv.Itoa(p.CommentCount)
+	default:
+		return pagination.TimeKey(p.CreatedAt)
+	}
+}
+
+// ParseCursorKey returns the sort key of a cursor made with CursorKey.
+func (s ListSort) ParseCursorKey(c pagination.Cursor) (any, error) {
+	switch s.Field {
+	case SortTitle:
+		return c.Key, nil
+	case SortCommentCount:
+		count, err := strconv.Atoi(c.Key)
+		if err != nil {
+			return nil, pagination.ErrInvalidCursor
+		}
+		return count, nil
+	default:
+		return c.Time()
+	}
+}
+
+// ParseListFilter reads the filters and order of a listing from the query
+// params, unknown sort fields and orders or malformed dates are violations.
+// Dates are RFC 3339 times, or days in UTC, which are included whole at the
+// end of a range.
+func ParseListFilter(query url.Values) (ListFilter, error) {
+	v := validation.Validator{}
+	tag := query.Get("tag")
+	filter := ListFilter{
+		Author: query.Get("author"),
+		Tag:    TagSlug(tag),
+		Sort:   ListSort{Field: SortField(query.Get("sort"))},
+	}
+	// A tag without a slug would match every post
+	v.Check(tag == "" || filter.Tag != "", "tag", validation.CodeInvalidFormat, "must contain a letter or digit")
+	filter.CreatedFrom = parseDate(&v, query, "created_from", false)
+	filter.CreatedTo = parseDate(&v, query, "created_to", true)
+	filter.UpdatedFrom = parseDate(&v, query, "updated_from", false)
+	filter.UpdatedTo = parseDate(&v, query, "updated_to", true)
+	v.Check(filter.CreatedTo.IsZero() || filter.CreatedTo.After(filter.CreatedFrom), "created_to", validation.CodeInvalidFormat, "must be after created_from")
+	v.Check(filter.UpdatedTo.IsZero() || filter.UpdatedTo.After(filter.UpdatedFrom), "updated_to", validation.CodeInvalidFormat, "must be after updated_from")
+
+	switch filter.Sort.Field {
+	case "":
+		filter.Sort.Field = SortCreatedAt
+	case SortCreatedAt, SortUpdatedAt, SortTitle, SortCommentCount:
+	default:
+		v.Check(false, "sort", validation.CodeInvalidFormat, "must be one of created_at, updated_at, title or comment_count")
+	}
+
+	// Titles are sorted alphabetically unless asked otherwise, the rest
+	// from the newest or most commented
+	switch query.Get("order") {
+	case "":
+		filter.Sort.Asc = filter.Sort.Field == SortTitle
+	case "asc":
+		filter.Sort.Asc = true
+	case "desc":
+	default:
+		v.Check(false, "order", validation.CodeInvalidFormat, "must be asc or desc")
+	}
+
+	return filter, v.Err()
+}
+
+func parseDate(v *validation.Validator, query url.Values, field string, end bool) time.Time {
+	value := query.Get(field)
+	if value == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		v.Check(false, field, validation.CodeInvalidFormat, "must be a date such as 2024-01-31 or a time such as 2024-01-31T15:04:05Z")
+		return time.Time{}
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
 }
 
 type PostListResponse struct {
-	Posts      []PostItem `json:"posts"`
-	TotalCount int        `json:"total_count" example:"100"`
-	Page       int        `json:"page" example:"1"`
-	PageSize   int        `json:"page_size" example:"10"`
+	Posts []PostItem `json:"posts"`
+	// TotalCount is only counted when asked for with `include_total=true`.
+	TotalCount *int `json:"total_count,omitempty" example:"100"`
+	// Page is left out for pages fetched by cursor.
+	Page     int `json:"page,omitempty" example:"1"`
+	PageSize int `json:"page_size" example:"10"`
+	// NextCursor and PrevCursor fetch the pages around this one when passed
+	// as `cursor`, they are left out when there is no such page.
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjU1MGU4NDAwLWUyOWItNDFkNC1hNzE2LTQ0NjY1NTQ0MDAwMCJ9"`
+	PrevCursor string `json:"prev_cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjU1MGU4NDAwLWUyOWItNDFkNC1hNzE2LTQ0NjY1NTQ0MDAwMCIsImIiOnRydWV9"`
 }
 
 func (p *PostWithAuthor) ToPostItem() PostItem {
@@ -77,7 +347,51 @@ func (p *PostWithAuthor) ToPostItem() PostItem {
 		Content:        p.Content,
 		AuthorID:       p.AuthorID,
 		AuthorUsername: p.AuthorUsername,
+		Status:         p.Status,
+		PublishedAt:    p.PublishedAt,
+		Tags:           []Tag{},
+		CommentCount:   p.CommentCount,
 		CreatedAt:      p.CreatedAt,
 		UpdatedAt:      p.UpdatedAt,
 	}
 }
+
+// Revision is a version of a post's title and content. Revisions are
+// numbered from 1 and the latest one is the current version.
+type Revision struct {
+	PostID    uuid.UUID
+	Revision  int
+	Title     string
+	Content   string
+	CreatedAt time.Time
+}
+
+type RevisionItem struct {
+	Revision  int       `json:"revision" example:"2"`
+	Title     string    `json:"title" example:"My First Blog Post"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type RevisionListResponse struct {
+	// Revisions are sorted from the latest.
+	Revisions []RevisionItem `json:"revisions"`
+}
+
+type RevisionResponse struct {
+	Revision  int       `json:"revision" example:"1"`
+	Title     string    `json:"title" example:"My First Blog Post"`
+	Content   string    `json:"content" example:"This is the content of my first blog post..."`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	// Diff turns the revision's content into the current content, deleted
+	// lines are only in the revision and inserted lines only in the current
+	// content.
+	Diff []diff.Line `json:"diff"`
+}
+
+func (r *Revision) ToRevisionItem() RevisionItem {
+	return RevisionItem{
+		Revision:  r.Revision,
+		Title:     r.Title,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -1,12 +1,18 @@
 package post
 
 import (
+	"net/http"
+
 	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
 )
 
 var (
-	ErrPostNotFound       = appError.New("POST_NOT_FOUND", "Post not found")
-	ErrInvalidInput       = appError.New("INVALID_INPUT", "Invalid input data")
-	ErrUnauthorized       = appError.New("UNAUTHORIZED", "You are not authorized to perform this action")
-	ErrSlugGenerationFail = appError.New("SLUG_GENERATION_FAIL", "Failed to generate unique slug")
+	ErrPostNotFound       = appError.New(http.StatusNotFound, "POST_NOT_FOUND", "Post not found")
+	ErrInvalidInput       = appError.New(http.StatusUnprocessableEntity, "INVALID_INPUT", "Invalid input data")
+	ErrUnauthorized       = appError.New(http.StatusForbidden, "UNAUTHORIZED", "You are not authorized to perform this action")
+	ErrSlugGenerationFail = appError.New(http.StatusConflict, "SLUG_GENERATION_FAIL", "Failed to generate unique slug")
+	ErrAuthorNotFound     = appError.New(http.StatusNotFound, "AUTHOR_NOT_FOUND", "Author not found")
+	ErrAlreadyPublished   = appError.New(http.StatusConflict, "POST_ALREADY_PUBLISHED", "Post is already published")
+	ErrRevisionNotFound   = appError.New(http.StatusNotFound, "REVISION_NOT_FOUND", "Revision not found")
+	ErrNotPublished       = appError.New(http.StatusConflict, "POST_NOT_PUBLISHED", "Post is neither published nor scheduled")
 )
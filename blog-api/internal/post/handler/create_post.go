@@ -12,7 +12,7 @@ import (
 
 // CreatePost godoc
 // @Summary      Create a new post
-// @Description  Create a new blog post
+// @Description  Create a new blog post, published right away unless it is created as a draft or scheduled for later
 // @Tags         posts
 // @Accept       json
 // @Produce      json
@@ -21,30 +21,31 @@ import (
 // @Success      201      {object}  server.APIResponse{message=string,result=post.PostID}  "Post created successfully"
 // @Failure      400      {object}  server.APIResponse{message=string,error=string}        "Invalid input"
 // @Failure      401      {object}  server.APIResponse{message=string,error=string}        "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}        "Email not verified"
 // @Failure      500      {object}  server.APIResponse{message=string,error=string}        "Internal server error"
 // @Router       /api/v1/posts [post]
 func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	claims, ok := server.GetUserClaims(r.Context())
 	if !ok {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
 		return
 	}
 
 	authorID, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Invalid user ID", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
 		return
 	}
 
 	var req post.CreatePostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
 		return
 	}
 
 	p, err := h.service.Create(r.Context(), authorID, req)
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
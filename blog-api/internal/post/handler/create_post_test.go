@@ -2,19 +2,25 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
 )
 
 func TestCreatePost_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	reqBody := post.CreatePostRequest{
 		Title:   "My First Post",
@@ -42,6 +48,72 @@ func TestCreatePost_Success(t *testing.T) {
 	}
 }
 
+// createAccessToken creates a personal access token with the given scopes for
+// the user of the auth token.
+func createAccessToken(t *testing.T, token string, scopes ...policy.Scope) string {
+	t.Helper()
+
+	body, _ := json.Marshal(user.CreatePersonalAccessTokenRequest{Name: "script", Scopes: scopes})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/tokens", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create access token: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result.(map[string]any)["token"].(string)
+}
+
+func TestCreatePost_PersonalAccessToken(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
+
+	tests := []struct {
+		name         string
+		scopes       []policy.Scope
+		expectedCode int
+	}{
+		{
+			name:         "With posts:write scope",
+			scopes:       []policy.Scope{policy.ScopePostsWrite},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Without posts:write scope",
+			scopes:       []policy.Scope{policy.ScopeCommentsWrite},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessToken := createAccessToken(t, token, tt.scopes...)
+
+			body, _ := json.Marshal(post.CreatePostRequest{
+				Title:   "Posted by a script " + tt.name,
+				Content: "This is the content.",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+			rec := httptest.NewRecorder()
+			testServer.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedCode {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestCreatePost_Unauthorized(t *testing.T) {
 	cleanup(t)
 
@@ -61,22 +133,116 @@ func TestCreatePost_Unauthorized(t *testing.T) {
 	}
 }
 
+func TestCreatePost_RequireVerifiedEmail(t *testing.T) {
+	cleanup(t)
+
+	// The shared test server does not require a verified email
+	srv := server.New(server.Config{Host: "localhost", Port: 8080})
+	srv.SetJWTMiddleware(server.NewJWTMiddleware(server.JWTConfig{
+		Keys:                 server.NewHMACKeySet(testJWTSecret),
+		TokenValidator:       testUserService,
+		RequireVerifiedEmail: true,
+		EmailVerification:    testUserService,
+	}))
+	testPostHandler.SetupRoutes(srv)
+
+	token := registerAndGetToken(t, "unverified", "unverified@example.com", "Str0ng-Passw0rd")
+	createPost := func(title string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		body, _ := json.Marshal(post.CreatePostRequest{
+			Title:   title,
+			Content: "This is the content.",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		srv.Mux().ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := createPost("Before verifying")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "EMAIL_NOT_VERIFIED" {
+		t.Errorf("Expected error 'EMAIL_NOT_VERIFIED', got '%v'", response.Error)
+	}
+
+	if _, err := testPool.Exec(context.Background(), "UPDATE users SET email_verified_at = NOW() WHERE email = $1", "unverified@example.com"); err != nil {
+		t.Fatalf("Failed to verify email: %v", err)
+	}
+
+	// The token issued before verifying is let through without a refresh
+	if rec := createPost("After verifying"); rec.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+}
+
 func TestCreatePost_InvalidInput(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	tests := []struct {
 		name    string
 		request post.CreatePostRequest
+		details []validation.Violation
 	}{
 		{
 			name:    "Empty title",
 			request: post.CreatePostRequest{Title: "", Content: "Some content"},
+			details: []validation.Violation{
+				{Field: "title", Code: validation.CodeRequired, Message: "is required"},
+			},
 		},
 		{
 			name:    "Empty content",
 			request: post.CreatePostRequest{Title: "Some title", Content: ""},
+			details: []validation.Violation{
+				{Field: "content", Code: validation.CodeRequired, Message: "is required"},
+			},
+		},
+		{
+			name:    "Title too long and empty content",
+			request: post.CreatePostRequest{Title: strings.Repeat("é", 201), Content: ""},
+			details: []validation.Violation{
+				{Field: "title", Code: validation.CodeTooLong, Message: "must be at most 200 characters"},
+				{Field: "content", Code: validation.CodeRequired, Message: "is required"},
+			},
+		},
+		{
+			name:    "Unknown status",
+			request: post.CreatePostRequest{Title: "Some title", Content: "Some content", Status: "hidden"},
+			details: []validation.Violation{
+				{Field: "status", Code: validation.CodeInvalidFormat, Message: "must be one of draft, scheduled or published"},
+			},
+		},
+		{
+			name:    "Scheduled without publish_at",
+			request: post.CreatePostRequest{Title: "Some title", Content: "Some content", Status: post.StatusScheduled},
+			details: []validation.Violation{
+				{Field: "publish_at", Code: validation.CodeRequired, Message: "is required"},
+			},
+		},
+		{
+			name:    "Tag without letters or digits",
+			request: post.CreatePostRequest{Title: "Some title", Content: "Some content", Tags: []string{"go", "!!!"}},
+			details: []validation.Violation{
+				{Field: "tags", Code: validation.CodeInvalidFormat, Message: `"!!!" must contain a letter or digit`},
+			},
+		},
+		{
+			name:    "Too many tags",
+			request: post.CreatePostRequest{Title: "Some title", Content: "Some content", Tags: strings.Split("a,b,c,d,e,f,g,h,i,j,k", ",")},
+			details: []validation.Violation{
+				{Field: "tags", Code: validation.CodeInvalidFormat, Message: "must have at most 10 tags"},
+			},
 		},
 	}
 
@@ -91,8 +257,78 @@ func TestCreatePost_InvalidInput(t *testing.T) {
 			testServer.Mux().ServeHTTP(rec, req)
 
 			if rec.Code != http.StatusUnprocessableEntity {
-				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+
+			var response server.APIResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error != "INVALID_INPUT" {
+				t.Errorf("Expected error 'INVALID_INPUT', got '%s'", response.Error)
+			}
+			if !slices.Equal(response.Details, tt.details) {
+				t.Errorf("Expected details %v, got %v", tt.details, response.Details)
 			}
 		})
 	}
 }
+
+func TestCreatePost_InvalidInputProblem(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "problemauthor", "problem@example.com", "Str0ng-Passw0rd")
+
+	body, _ := json.Marshal(post.CreatePostRequest{Title: "", Content: "Some content"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != server.ProblemContentType {
+		t.Errorf("Expected content type '%s', got '%s'", server.ProblemContentType, contentType)
+	}
+
+	var problem server.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to parse problem: %v", err)
+	}
+	if problem.Type != "urn:problem:invalid-input" {
+		t.Errorf("Expected type 'urn:problem:invalid-input', got '%s'", problem.Type)
+	}
+	if problem.Status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d in the problem, got %d", http.StatusUnprocessableEntity, problem.Status)
+	}
+	if problem.Code != "INVALID_INPUT" {
+		t.Errorf("Expected code 'INVALID_INPUT', got '%s'", problem.Code)
+	}
+	details := []validation.Violation{
+		{Field: "title", Code: validation.CodeRequired, Message: "is required"},
+	}
+	if !slices.Equal(problem.Details, details) {
+		t.Errorf("Expected details %v, got %v", details, problem.Details)
+	}
+}
+
+func TestCreatePost_TitleAtMaxLength(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "longtitle", "longtitle@example.com", "Str0ng-Passw0rd")
+
+	// The limit counts characters, not bytes
+	body, _ := json.Marshal(post.CreatePostRequest{Title: strings.Repeat("é", 200), Content: "Some content"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+}
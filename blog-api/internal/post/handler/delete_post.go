@@ -5,45 +5,47 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
 // DeletePost godoc
 // @Summary      Delete a post
-// @Description  Delete an existing blog post (only the author can delete)
+// @Description  Delete an existing blog post (the author, a moderator or an admin can delete)
 // @Tags         posts
 // @Produce      json
 // @Security     BearerAuth
 // @Param        postId  path      string  true  "Post ID"
 // @Success      200     {object}  server.APIResponse{message=string,result=post.PostID}  "Post deleted successfully"
 // @Failure      401     {object}  server.APIResponse{message=string,error=string}        "Unauthorized"
-// @Failure      403     {object}  server.APIResponse{message=string,error=string}        "Forbidden - not the author"
+// @Failure      403     {object}  server.APIResponse{message=string,error=string}        "Forbidden - not the author or a moderator"
 // @Failure      404     {object}  server.APIResponse{message=string,error=string}        "Post not found"
 // @Failure      500     {object}  server.APIResponse{message=string,error=string}        "Internal server error"
 // @Router       /api/v1/posts/{postId} [delete]
 func (h *Handler) DeletePost(w http.ResponseWriter, r *http.Request) {
 	claims, ok := server.GetUserClaims(r.Context())
 	if !ok {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
 		return
 	}
 
-	authorID, err := uuid.Parse(claims.UserID)
+	userID, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Invalid user ID", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
 		return
 	}
+	actor := policy.Actor{UserID: userID, Role: claims.Role}
 
 	postIDStr := r.PathValue("postId")
 	postID, err := uuid.Parse(postIDStr)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid post ID", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid post ID", nil)
 		return
 	}
 
-	p, err := h.service.Delete(r.Context(), postID, authorID)
+	p, err := h.service.Delete(r.Context(), postID, actor)
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
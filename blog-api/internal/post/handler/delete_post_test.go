@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
@@ -14,7 +15,7 @@ import (
 func TestDeletePost_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	// Create a post
 	createReq := post.CreatePostRequest{
@@ -64,7 +65,7 @@ func TestDeletePost_NotAuthor(t *testing.T) {
 	cleanup(t)
 
 	// User 1 creates a post
-	token1 := registerAndGetToken(t, "author1", "author1@example.com", "password123")
+	token1 := registerAndGetToken(t, "author1", "author1@example.com", "Str0ng-Passw0rd")
 
 	createReq := post.CreatePostRequest{
 		Title:   "Author1 Post",
@@ -85,7 +86,7 @@ func TestDeletePost_NotAuthor(t *testing.T) {
 	postID := result["id"].(string)
 
 	// User 2 tries to delete
-	token2 := registerAndGetToken(t, "author2", "author2@example.com", "password123")
+	token2 := registerAndGetToken(t, "author2", "author2@example.com", "Str0ng-Passw0rd")
 
 	req = httptest.NewRequest(http.MethodDelete, "/api/v1/posts/"+postID, nil)
 	req.Header.Set("Authorization", "Bearer "+token2)
@@ -97,6 +98,56 @@ func TestDeletePost_NotAuthor(t *testing.T) {
 	}
 }
 
+func TestDeletePost_Moderator(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "author1", "author1@example.com", "Str0ng-Passw0rd")
+
+	createReq := post.CreatePostRequest{
+		Title:   "Post to Moderate",
+		Content: "Content by author1.",
+	}
+	body, _ := json.Marshal(createReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	var createResponse server.APIResponse
+	//nolint:errcheck
+	json.Unmarshal(rec.Body.Bytes(), &createResponse)
+	result := createResponse.Result.(map[string]any)
+	postID := result["id"].(string)
+
+	moderatorToken := registerWithRole(t, "moderator", "moderator@example.com", "Str0ng-Passw0rd", policy.RoleModerator)
+
+	// Moderators can remove the post but not edit it
+	updateBody, _ := json.Marshal(post.UpdatePostRequest{
+		Title:   "Moderated Title",
+		Content: "Moderated content.",
+	})
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/posts/"+postID, bytes.NewReader(updateBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+moderatorToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected update status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/posts/"+postID, nil)
+	req.Header.Set("Authorization", "Bearer "+moderatorToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
 func TestDeletePost_Unauthorized(t *testing.T) {
 	cleanup(t)
 
@@ -112,7 +163,7 @@ func TestDeletePost_Unauthorized(t *testing.T) {
 func TestDeletePost_NotFound(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/posts/550e8400-e29b-41d4-a716-446655440000", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
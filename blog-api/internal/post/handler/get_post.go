@@ -3,29 +3,39 @@ package handler
 import (
 	"net/http"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
 // GetPostBySlug godoc
 // @Summary      Get post by slug
-// @Description  Get a single blog post by its slug
+// @Description  Get a single published blog post by its slug, the author also gets their unpublished posts. The content is Markdown, or its sanitized HTML with a table of contents of its headings with format=html
 // @Tags         posts
 // @Produce      json
-// @Param        slug  path      string  true  "Post slug"
-// @Success      200   {object}  server.APIResponse{message=string,result=post.PostItem}  "Post retrieved successfully"
-// @Failure      404   {object}  server.APIResponse{message=string,error=string}          "Post not found"
-// @Failure      500   {object}  server.APIResponse{message=string,error=string}          "Internal server error"
+// @Param        slug    path      string  true   "Post slug"
+// @Param        format  query     string  false  "Format of the content"  Enums(markdown,html)  default(markdown)
+// @Success      200     {object}  server.APIResponse{message=string,result=post.PostItem}  "Post retrieved successfully"
+// @Failure      401     {object}  server.APIResponse{message=string,error=string}          "Invalid token"
+// @Failure      404     {object}  server.APIResponse{message=string,error=string}          "Post not found"
+// @Failure      422     {object}  server.APIResponse{message=string,error=string}          "Invalid format"
+// @Failure      500     {object}  server.APIResponse{message=string,error=string}          "Internal server error"
 // @Router       /api/v1/posts/{slug} [get]
 func (h *Handler) GetPostBySlug(w http.ResponseWriter, r *http.Request) {
 	slug := r.PathValue("slug")
 	if slug == "" {
-		server.ErrorResponse(w, http.StatusBadRequest, "Post slug is required", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Post slug is required", nil)
 		return
 	}
 
-	p, err := h.service.GetBySlug(r.Context(), slug)
+	format, err := post.ParseFormat(r.URL.Query().Get("format"))
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
+		return
+	}
+
+	p, err := h.service.GetBySlug(r.Context(), slug, viewerID(r), format)
+	if err != nil {
+		server.HandleError(w, r, err)
 		return
 	}
 
@@ -2,19 +2,24 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/markdown"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
 func TestGetPostBySlug_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	// Create a post
 	reqBody := post.CreatePostRequest{
@@ -81,3 +86,246 @@ func TestGetPostBySlug_NotFound(t *testing.T) {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
 	}
 }
+
+func TestGetPostBySlug_NotFoundProblem(t *testing.T) {
+	cleanup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/non-existent-slug", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != server.ProblemContentType {
+		t.Errorf("Expected content type '%s', got '%s'", server.ProblemContentType, contentType)
+	}
+
+	var problem server.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to parse problem: %v", err)
+	}
+	expected := server.Problem{
+		Type:     "urn:problem:post-not-found",
+		Title:    post.ErrPostNotFound.Message,
+		Status:   http.StatusNotFound,
+		Instance: "/api/v1/posts/non-existent-slug",
+		Code:     "POST_NOT_FOUND",
+	}
+	if !reflect.DeepEqual(problem, expected) {
+		t.Errorf("Expected problem %+v, got %+v", expected, problem)
+	}
+}
+
+func TestGetPostBySlug_Draft(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "slugdrafter", "slugdrafter@example.com", "Str0ng-Passw0rd")
+	otherToken := registerAndGetToken(t, "slugreader", "slugreader@example.com", "Str0ng-Passw0rd")
+	_, slug := createPostWithStatus(t, authorToken, post.CreatePostRequest{
+		Title:   "Work in progress",
+		Content: "Some content",
+		Status:  post.StatusDraft,
+	})
+
+	if rec := getPost(t, "", slug); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected draft to be hidden from anonymous users, got %d", rec.Code)
+	}
+	if rec := getPost(t, otherToken, slug); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected draft to be hidden from other users, got %d", rec.Code)
+	}
+
+	rec := getPost(t, authorToken, slug)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the author to see the draft, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var response struct {
+		Result post.PostItem `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Result.Status != post.StatusDraft {
+		t.Errorf("Expected status '%s', got '%s'", post.StatusDraft, response.Result.Status)
+	}
+	if response.Result.PublishedAt != nil {
+		t.Errorf("Expected no published_at, got %v", response.Result.PublishedAt)
+	}
+}
+
+func TestGetPostBySlug_DeletedAuthor(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "leavingauthor", "leaving@example.com", "Str0ng-Passw0rd")
+
+	body, _ := json.Marshal(post.CreatePostRequest{
+		Title:   "Post Outliving Its Author",
+		Content: "This is the content.",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create post: %s", rec.Body.String())
+	}
+
+	body, _ = json.Marshal(user.DeleteAccountRequest{Password: "Str0ng-Passw0rd"})
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Failed to delete account: %s", rec.Body.String())
+	}
+
+	// The post is kept under a placeholder author
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	var listResponse server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &listResponse); err != nil {
+		t.Fatalf("Failed to parse list response: %v", err)
+	}
+	posts := listResponse.Result.(map[string]any)["posts"].([]any)
+	if len(posts) != 1 {
+		t.Fatalf("Expected the post to be kept, got %d posts", len(posts))
+	}
+	slug := posts[0].(map[string]any)["slug"].(string)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/posts/"+slug, nil)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	postResult := response.Result.(map[string]any)
+	if postResult["author_username"] != "deleted user" {
+		t.Errorf("Expected author_username 'deleted user', got '%v'", postResult["author_username"])
+	}
+}
+
+func TestGetPostBySlug_HTML(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "markdowner", "markdowner@example.com", "Str0ng-Passw0rd")
+	postID, slug := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title: "Markdown post",
+		Content: "# Guide\n\n" +
+			"Some **bold** text <script>alert('xss')</script>\n\n" +
+			"## Getting *started*\n\n" +
+			"[link](javascript:alert(1)) <img src=\"cat.png\" onerror=\"alert(1)\">\n\n" +
+			"- [x] done\n",
+	})
+
+	readHTML := func() post.PostItem {
+		t.Helper()
+
+		rec := getPost(t, "", slug+"?format=html")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response struct {
+			Result post.PostItem `json:"result"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response.Result
+	}
+
+	result := readHTML()
+	if result.Content != "" {
+		t.Errorf("Expected no markdown content, got '%s'", result.Content)
+	}
+	for _, expected := range []string{
+		`<h1 id="guide">Guide</h1>`,
+		`<strong>bold</strong>`,
+		`<h2 id="getting-started">Getting <em>started</em></h2>`,
+		`<img src="cat.png">`,
+		`type="checkbox"`,
+	} {
+		if !strings.Contains(result.ContentHTML, expected) {
+			t.Errorf("Expected HTML to contain '%s', got '%s'", expected, result.ContentHTML)
+		}
+	}
+	for _, unexpected := range []string{"<script", "alert('xss')", "onerror", "javascript:"} {
+		if strings.Contains(result.ContentHTML, unexpected) {
+			t.Errorf("Expected HTML not to contain '%s', got '%s'", unexpected, result.ContentHTML)
+		}
+	}
+	expectedTOC := []markdown.Heading{
+		{Level: 1, Text: "Guide", ID: "guide"},
+		{Level: 2, Text: "Getting started", ID: "getting-started"},
+	}
+	if !reflect.DeepEqual(result.TOC, expectedTOC) {
+		t.Errorf("Expected TOC %+v, got %+v", expectedTOC, result.TOC)
+	}
+
+	// Posts saved before the HTML was stored are rendered when read
+	if _, err := testPool.Exec(context.Background(), "UPDATE posts SET content_html = NULL WHERE id = $1", postID); err != nil {
+		t.Fatalf("Failed to clear the HTML: %v", err)
+	}
+	if rendered := readHTML(); rendered.ContentHTML != result.ContentHTML {
+		t.Errorf("Expected HTML '%s', got '%s'", result.ContentHTML, rendered.ContentHTML)
+	}
+}
+
+func TestGetPostBySlug_Markdown(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "markdowner", "markdowner@example.com", "Str0ng-Passw0rd")
+	_, slug := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Markdown post",
+		Content: "## Heading\n\nSome **bold** text",
+	})
+
+	for _, path := range []string{slug, slug + "?format=markdown"} {
+		rec := getPost(t, "", path)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for '%s', got %d. Body: %s", http.StatusOK, path, rec.Code, rec.Body.String())
+		}
+		var response struct {
+			Result map[string]any `json:"result"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Result["content"] != "## Heading\n\nSome **bold** text" {
+			t.Errorf("Expected the markdown content for '%s', got '%v'", path, response.Result["content"])
+		}
+		for _, field := range []string{"content_html", "toc"} {
+			if _, ok := response.Result[field]; ok {
+				t.Errorf("Expected no '%s' for '%s', got '%v'", field, path, response.Result[field])
+			}
+		}
+	}
+}
+
+func TestGetPostBySlug_InvalidFormat(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "markdowner", "markdowner@example.com", "Str0ng-Passw0rd")
+	_, slug := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Markdown post",
+		Content: "Some content",
+	})
+
+	rec := getPost(t, "", slug+"?format=pdf")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// GetRevision godoc
+// @Summary      Get a revision of a post
+// @Description  Get a saved version of a post with a line diff from it to the current content (only the author or a moderator can see revisions)
+// @Tags         posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postId    path      string  true  "Post ID"
+// @Param        revision  path      int     true  "Revision number"
+// @Success      200       {object}  server.APIResponse{message=string,result=post.RevisionResponse}  "Revision retrieved successfully"
+// @Failure      400       {object}  server.APIResponse{message=string,error=string}                  "Invalid post ID or revision"
+// @Failure      401       {object}  server.APIResponse{message=string,error=string}                  "Unauthorized"
+// @Failure      403       {object}  server.APIResponse{message=string,error=string}                  "Forbidden - not the author or a moderator"
+// @Failure      404       {object}  server.APIResponse{message=string,error=string}                  "Post or revision not found"
+// @Failure      500       {object}  server.APIResponse{message=string,error=string}                  "Internal server error"
+// @Router       /api/v1/posts/{postId}/revisions/{revision} [get]
+func (h *Handler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+	actor := policy.Actor{UserID: userID, Role: claims.Role}
+
+	postID, err := uuid.Parse(r.PathValue("postId"))
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid post ID", nil)
+		return
+	}
+
+	revision, err := strconv.Atoi(r.PathValue("revision"))
+	if err != nil || revision < 1 {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid revision", nil)
+		return
+	}
+
+	result, err := h.service.GetRevision(r.Context(), postID, revision, actor)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Revision retrieved successfully",
+		Result:  result,
+	})
+}
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/diff"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func TestGetRevision_Diff(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "differ", "differ@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Diffed post",
+		Content: "Intro\nOld middle\nOutro",
+	})
+	updatePost(t, token, postID, "Diffed post", "Intro\nNew middle\nOutro\nP.S.")
+
+	rec := revisionsRequest(t, http.MethodGet, token, postID+"/revisions/1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result post.RevisionResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Result.Content != "Intro\nOld middle\nOutro" {
+		t.Errorf("Expected the content of revision 1, got '%s'", response.Result.Content)
+	}
+	expected := []diff.Line{
+		{Op: diff.OpEqual, Text: "Intro"},
+		{Op: diff.OpDelete, Text: "Old middle"},
+		{Op: diff.OpInsert, Text: "New middle"},
+		{Op: diff.OpEqual, Text: "Outro"},
+		{Op: diff.OpInsert, Text: "P.S."},
+	}
+	if !slices.Equal(response.Result.Diff, expected) {
+		t.Errorf("Expected diff %v, got %v", expected, response.Result.Diff)
+	}
+}
+
+func TestGetRevision_NotFound(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "norevision", "norevision@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{Title: "Single revision", Content: "Some content"})
+
+	tests := []struct {
+		name           string
+		revision       string
+		expectedStatus int
+	}{
+		{name: "Unknown revision", revision: "2", expectedStatus: http.StatusNotFound},
+		{name: "Not a number", revision: "latest", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := revisionsRequest(t, http.MethodGet, token, postID+"/revisions/"+tt.revision)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"net/http"
 
-	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post/service"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
@@ -18,28 +20,35 @@ func New(svc *service.Service) *Handler {
 	}
 }
 
-func (h *Handler) SetupRoutes(server *server.Server) {
-	// Public routes
-	server.HandleFunc("GET /api/v1/posts", h.ListPosts)
-	server.HandleFunc("GET /api/v1/posts/{slug}", h.GetPostBySlug)
+func (h *Handler) SetupRoutes(srv *server.Server) {
+	// Public routes, authors also see their unpublished posts
+	srv.HandleFuncWithOptionalAuth("GET /api/v1/posts", h.ListPosts)
+	srv.HandleFuncWithOptionalAuth("GET /api/v1/posts/search", h.SearchPosts)
+	srv.HandleFuncWithOptionalAuth("GET /api/v1/posts/{slug}", h.GetPostBySlug)
+	srv.HandleFuncWithOptionalAuth("GET /api/v1/users/{username}/posts", h.ListAuthorPosts)
+	srv.HandleFunc("GET /api/v1/tags", h.ListTags)
 
 	// Protected routes
-	server.HandleFuncWithAuth("POST /api/v1/posts", h.CreatePost)
-	server.HandleFuncWithAuth("PUT /api/v1/posts/{postId}", h.UpdatePost)
-	server.HandleFuncWithAuth("DELETE /api/v1/posts/{postId}", h.DeletePost)
+	srv.HandleFuncWithAuth("POST /api/v1/posts", h.CreatePost, server.RequireVerifiedEmail(), server.RequireScope(policy.ScopePostsWrite))
+	srv.HandleFuncWithAuth("PUT /api/v1/posts/{postId}", h.UpdatePost, server.RequireScope(policy.ScopePostsWrite))
+	srv.HandleFuncWithAuth("DELETE /api/v1/posts/{postId}", h.DeletePost, server.RequireScope(policy.ScopePostsWrite))
+	srv.HandleFuncWithAuth("POST /api/v1/posts/{postId}/publish", h.PublishPost, server.RequireScope(policy.ScopePostsWrite))
+	srv.HandleFuncWithAuth("POST /api/v1/posts/{postId}/unpublish", h.UnpublishPost, server.RequireScope(policy.ScopePostsWrite))
+	srv.HandleFuncWithAuth("GET /api/v1/posts/{postId}/revisions", h.ListRevisions)
+	srv.HandleFuncWithAuth("GET /api/v1/posts/{postId}/revisions/{revision}", h.GetRevision)
+	srv.HandleFuncWithAuth("POST /api/v1/posts/{postId}/revisions/{revision}/restore", h.RestoreRevision, server.RequireScope(policy.ScopePostsWrite))
 }
 
-func (h *Handler) handleError(w http.ResponseWriter, err error) {
-	switch err {
-	case post.ErrInvalidInput:
-		server.ErrorResponse(w, http.StatusUnprocessableEntity, "", err)
-	case post.ErrPostNotFound:
-		server.ErrorResponse(w, http.StatusNotFound, "", err)
-	case post.ErrUnauthorized:
-		server.ErrorResponse(w, http.StatusForbidden, "", err)
-	case post.ErrSlugGenerationFail:
-		server.ErrorResponse(w, http.StatusConflict, "", err)
-	default:
-		server.ErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+// viewerID returns the ID of the user on routes with optional
+// authentication, or uuid.Nil for anonymous requests.
+func viewerID(r *http.Request) uuid.UUID {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		return uuid.Nil
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil
 	}
+	return userID
 }
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// ListAuthorPosts godoc
+// @Summary      List posts of an author
+// @Description  Get a paginated list of the published blog posts written by a user, or all of them when it is the authenticated user
+// @Tags         posts
+// @Produce      json
+// @Param        username       path      string  true   "Author username"
+// @Param        page           query     int     false  "Page number, ignored with a cursor"  default(1)
+// @Param        page_size      query     int     false  "Page size"                           default(10)
+// @Param        cursor         query     string  false  "Cursor of the page from next_cursor or prev_cursor"
+// @Param        include_total  query     bool    false  "Count the posts of all pages"
+// @Success      200            {object}  server.APIResponse{message=string,result=post.PostListResponse}  "Posts retrieved successfully"
+// @Failure      400            {object}  server.APIResponse{message=string,error=string}                  "Invalid cursor"
+// @Failure      401            {object}  server.APIResponse{message=string,error=string}                  "Invalid token"
+// @Failure      404            {object}  server.APIResponse{message=string,error=string}                  "Author not found"
+// @Failure      500            {object}  server.APIResponse{message=string,error=string}                  "Internal server error"
+// @Router       /api/v1/users/{username}/posts [get]
+func (h *Handler) ListAuthorPosts(w http.ResponseWriter, r *http.Request) {
+	p, err := pagination.FromRequest(r)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	result, err := h.service.ListByAuthor(r.Context(), r.PathValue("username"), viewerID(r), p)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	pagination.SetLinks(w, r, result.NextCursor, result.PrevCursor)
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Posts retrieved successfully",
+		Result:  result,
+	})
+}
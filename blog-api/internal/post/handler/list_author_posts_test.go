@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+func createPost(t *testing.T, token, title string) {
+	t.Helper()
+
+	body, _ := json.Marshal(post.CreatePostRequest{Title: title, Content: "Content of " + title})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create post: %s", rec.Body.String())
+	}
+}
+
+func listAuthorPosts(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListAuthorPosts_Success(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "listauthor", "listauthor@example.com", "Str0ng-Passw0rd")
+	otherToken := registerAndGetToken(t, "otherauthor", "otherauthor@example.com", "Str0ng-Passw0rd")
+
+	for i := 1; i <= 3; i++ {
+		createPost(t, authorToken, fmt.Sprintf("Author post %d", i))
+	}
+	createPost(t, otherToken, "Other post")
+
+	rec := listAuthorPosts(t, "/api/v1/users/listauthor/posts?page=1&page_size=2&include_total=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result post.PostListResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Result.TotalCount == nil || *response.Result.TotalCount != 3 {
+		t.Errorf("Expected total count 3, got %v", response.Result.TotalCount)
+	}
+	if len(response.Result.Posts) != 2 {
+		t.Errorf("Expected 2 posts on the page, got %d", len(response.Result.Posts))
+	}
+	for _, p := range response.Result.Posts {
+		if p.AuthorUsername != "listauthor" {
+			t.Errorf("Expected only posts of 'listauthor', got one of '%s'", p.AuthorUsername)
+		}
+	}
+}
+
+func TestListAuthorPosts_NoPosts(t *testing.T) {
+	cleanup(t)
+
+	registerAndGetToken(t, "quietauthor", "quietauthor@example.com", "Str0ng-Passw0rd")
+
+	rec := listAuthorPosts(t, "/api/v1/users/quietauthor/posts")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if posts := response.Result.(map[string]any)["posts"].([]any); len(posts) != 0 {
+		t.Errorf("Expected no posts, got %d", len(posts))
+	}
+}
+
+func TestListAuthorPosts_UnknownAuthor(t *testing.T) {
+	cleanup(t)
+
+	rec := listAuthorPosts(t, "/api/v1/users/nobody/posts")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
+
+func TestListAuthorPosts_OwnDrafts(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "ownlisting", "ownlisting@example.com", "Str0ng-Passw0rd")
+	createPost(t, token, "Published post")
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Draft post", Content: "Some content", Status: post.StatusDraft})
+
+	var response struct {
+		Result post.PostListResponse `json:"result"`
+	}
+
+	rec := listAuthorPosts(t, "/api/v1/users/ownlisting/posts")
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Result.Posts) != 1 {
+		t.Errorf("Expected only the published post for anonymous users, got %d", len(response.Result.Posts))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/ownlisting/posts", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Result.Posts) != 2 {
+		t.Errorf("Expected the author to also see the draft, got %d", len(response.Result.Posts))
+	}
+}
@@ -2,43 +2,57 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
 
 // ListPosts godoc
 // @Summary      List all posts
-// @Description  Get a paginated list of all blog posts
+// @Description  Get a paginated list of the published blog posts. Authenticated users also get their own unpublished posts.
 // @Tags         posts
 // @Produce      json
-// @Param        page      query     int  false  "Page number"  default(1)
-// @Param        page_size query     int  false  "Page size"    default(10)
-// @Success      200       {object}  server.APIResponse{message=string,result=post.PostListResponse}  "Posts retrieved successfully"
-// @Failure      500       {object}  server.APIResponse{message=string,error=string}                  "Internal server error"
+// @Param        page           query     int     false  "Page number, ignored with a cursor"  default(1)
+// @Param        page_size      query     int     false  "Page size"                           default(10)
+// @Param        cursor         query     string  false  "Cursor of the page from next_cursor or prev_cursor"
+// @Param        include_total  query     bool    false  "Count the posts of all pages"
+// @Param        tag            query     string  false  "Only posts with this tag, by slug or name"
+// @Param        author         query     string  false  "Only posts of the user with this username"
+// @Param        created_from   query     string  false  "Only posts created at or after this date or time"  example(2024-01-01)
+// @Param        created_to     query     string  false  "Only posts created before this time, or on or before this date"  example(2024-01-31)
+// @Param        updated_from   query     string  false  "Only posts updated at or after this date or time"
+// @Param        updated_to     query     string  false  "Only posts updated before this time, or on or before this date"
+// @Param        sort           query     string  false  "Sort field"  Enums(created_at,updated_at,title,comment_count)  default(created_at)
+// @Param        order          query     string  false  "Sort order, ascending by default for title and descending otherwise"  Enums(asc,desc)
+// @Success      200            {object}  server.APIResponse{message=string,result=post.PostListResponse}  "Posts retrieved successfully"
+// @Failure      400            {object}  server.APIResponse{message=string,error=string}                  "Invalid cursor"
+// @Failure      401            {object}  server.APIResponse{message=string,error=string}                  "Invalid token"
+// @Failure      422            {object}  server.APIResponse{message=string,error=string}                  "Invalid filter or sort"
+// @Failure      500            {object}  server.APIResponse{message=string,error=string}                  "Internal server error"
 // @Router       /api/v1/posts [get]
 func (h *Handler) ListPosts(w http.ResponseWriter, r *http.Request) {
-	page := 1
-	pageSize := 10
-
-	if p := r.URL.Query().Get("page"); p != "" {
-		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
-			page = parsed
-		}
+	p, err := pagination.FromRequest(r)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
 	}
 
-	if ps := r.URL.Query().Get("page_size"); ps != "" {
-		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 {
-			pageSize = parsed
-		}
+	filter, err := post.ParseListFilter(r.URL.Query())
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
 	}
+	filter.ViewerID = viewerID(r)
 
-	result, err := h.service.List(r.Context(), page, pageSize)
+	result, err := h.service.List(r.Context(), filter, p)
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
+	pagination.SetLinks(w, r, result.NextCursor, result.PrevCursor)
+
 	server.JSON(w, http.StatusOK, server.APIResponse{
 		Message: "Posts retrieved successfully",
 		Result:  result,
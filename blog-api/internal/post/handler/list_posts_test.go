@@ -2,20 +2,24 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
 )
 
 func TestListPosts_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	// Create a few posts
 	for i := 1; i <= 3; i++ {
@@ -60,7 +64,7 @@ func TestListPosts_Success(t *testing.T) {
 func TestListPosts_Pagination(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	// Create 5 posts
 	for i := 1; i <= 5; i++ {
@@ -78,7 +82,7 @@ func TestListPosts_Pagination(t *testing.T) {
 	}
 
 	// Request page 1 with page_size 2
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts?page=1&page_size=2", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts?page=1&page_size=2&include_total=true", nil)
 	rec := httptest.NewRecorder()
 	testServer.Mux().ServeHTTP(rec, req)
 
@@ -100,3 +104,352 @@ func TestListPosts_Pagination(t *testing.T) {
 		t.Errorf("Expected total_count 5, got %v", result["total_count"])
 	}
 }
+
+func TestListPosts_Unpublished(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "draftwriter", "draftwriter@example.com", "Str0ng-Passw0rd")
+	otherToken := registerAndGetToken(t, "draftreader", "draftreader@example.com", "Str0ng-Passw0rd")
+
+	createPostWithStatus(t, authorToken, post.CreatePostRequest{Title: "Published post", Content: "Some content"})
+	createPostWithStatus(t, authorToken, post.CreatePostRequest{Title: "Draft post", Content: "Some content", Status: post.StatusDraft})
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedCount int
+	}{
+		{name: "Anonymous", token: "", expectedCount: 1},
+		{name: "Other user", token: otherToken, expectedCount: 1},
+		{name: "Author", token: authorToken, expectedCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			testServer.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var response struct {
+				Result post.PostListResponse `json:"result"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if len(response.Result.Posts) != tt.expectedCount {
+				t.Errorf("Expected %d posts, got %d", tt.expectedCount, len(response.Result.Posts))
+			}
+		})
+	}
+}
+
+func TestListPosts_InvalidToken(t *testing.T) {
+	cleanup(t)
+
+	// A token that is sent must be valid even on public routes
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+	req.Header.Set("Authorization", "Bearer not-a-valid-token")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestListPosts_FilterByTag(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "tagfilter", "tagfilter@example.com", "Str0ng-Passw0rd")
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Tagged post", Content: "Some content", Tags: []string{"Machine Learning", "Go"}})
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Untagged post", Content: "Some content"})
+
+	tests := []struct {
+		name          string
+		tag           string
+		expectedCount int
+	}{
+		{name: "Slug", tag: "machine-learning", expectedCount: 1},
+		{name: "Name", tag: "Machine%20Learning", expectedCount: 1},
+		{name: "Unknown tag", tag: "rust", expectedCount: 0},
+		{name: "No tag", tag: "", expectedCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/posts?tag="+tt.tag, nil)
+			rec := httptest.NewRecorder()
+			testServer.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var response struct {
+				Result post.PostListResponse `json:"result"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if len(response.Result.Posts) != tt.expectedCount {
+				t.Fatalf("Expected %d posts, got %d", tt.expectedCount, len(response.Result.Posts))
+			}
+
+			// Each post comes with all of its tags
+			for _, p := range response.Result.Posts {
+				expected := []post.Tag{}
+				if p.Title == "Tagged post" {
+					expected = []post.Tag{{Slug: "go", Name: "Go"}, {Slug: "machine-learning", Name: "Machine Learning"}}
+				}
+				if !slices.Equal(p.Tags, expected) {
+					t.Errorf("Expected tags %v on '%s', got %v", expected, p.Title, p.Tags)
+				}
+			}
+		})
+	}
+}
+
+func listPostsPage(t *testing.T, url string) (post.PostListResponse, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result post.PostListResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result, rec.Header().Get("Link")
+}
+
+func TestListPosts_Cursor(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "cursorauthor", "cursorauthor@example.com", "Str0ng-Passw0rd")
+	for i := 1; i <= 5; i++ {
+		createPost(t, token, fmt.Sprintf("Post %d", i))
+	}
+
+	first, link := listPostsPage(t, "/api/v1/posts?page_size=2")
+	if first.TotalCount != nil {
+		t.Errorf("Expected no total count unless asked for, got %d", *first.TotalCount)
+	}
+	if first.NextCursor == "" || first.PrevCursor != "" {
+		t.Fatalf("Expected only a next cursor on the first page, got next '%s' and prev '%s'", first.NextCursor, first.PrevCursor)
+	}
+	expectedLink := `</api/v1/posts?cursor=` + first.NextCursor + `&page_size=2>; rel="next"`
+	if link != expectedLink {
+		t.Errorf("Expected Link header '%s', got '%s'", expectedLink, link)
+	}
+
+	// A post created meanwhile does not shift the following pages
+	createPost(t, token, "Post 6")
+
+	titles := []string{}
+	page := first
+	for {
+		for _, p := range page.Posts {
+			titles = append(titles, p.Title)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		page, _ = listPostsPage(t, "/api/v1/posts?page_size=2&cursor="+page.NextCursor)
+		if page.Page != 0 {
+			t.Errorf("Expected no page number for pages fetched by cursor, got %d", page.Page)
+		}
+	}
+
+	expected := []string{"Post 5", "Post 4", "Post 3", "Post 2", "Post 1"}
+	if !slices.Equal(titles, expected) {
+		t.Fatalf("Expected posts %v, got %v", expected, titles)
+	}
+
+	second, _ := listPostsPage(t, "/api/v1/posts?page_size=2&cursor="+first.NextCursor)
+	previous, _ := listPostsPage(t, "/api/v1/posts?page_size=2&cursor="+second.PrevCursor)
+	titles = []string{}
+	for _, p := range previous.Posts {
+		titles = append(titles, p.Title)
+	}
+	expected = []string{"Post 5", "Post 4"}
+	if !slices.Equal(titles, expected) {
+		t.Errorf("Expected previous page %v, got %v", expected, titles)
+	}
+	if previous.PrevCursor == "" {
+		t.Error("Expected a prev cursor to the post created meanwhile")
+	}
+}
+
+func TestListPosts_InvalidCursor(t *testing.T) {
+	cleanup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts?cursor=not-a-cursor", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "INVALID_CURSOR" {
+		t.Errorf("Expected error 'INVALID_CURSOR', got '%s'", response.Error)
+	}
+}
+
+func TestListPosts_FilterAndSort(t *testing.T) {
+	cleanup(t)
+
+	aliceToken := registerAndGetToken(t, "alice", "alice@example.com", "Str0ng-Passw0rd")
+	bobToken := registerAndGetToken(t, "bob", "bob@example.com", "Str0ng-Passw0rd")
+
+	posts := []struct {
+		token     string
+		title     string
+		createdAt string
+		updatedAt string
+		comments  int
+	}{
+		{token: aliceToken, title: "Banana", createdAt: "2024-01-10T08:00:00Z", updatedAt: "2024-01-10T08:00:00Z", comments: 2},
+		{token: bobToken, title: "Cherry", createdAt: "2024-02-10T08:00:00Z", updatedAt: "2024-04-01T08:00:00Z", comments: 1},
+		{token: aliceToken, title: "Apple", createdAt: "2024-03-10T08:00:00Z", updatedAt: "2024-03-20T08:00:00Z", comments: 0},
+	}
+	for _, p := range posts {
+		postID, _ := createPostWithStatus(t, p.token, post.CreatePostRequest{Title: p.title, Content: "Some content"})
+		createdAt, _ := time.Parse(time.RFC3339, p.createdAt)
+		updatedAt, _ := time.Parse(time.RFC3339, p.updatedAt)
+		if _, err := testPool.Exec(context.Background(), "UPDATE posts SET created_at = $1, updated_at = $2 WHERE id = $3", createdAt, updatedAt, postID); err != nil {
+			t.Fatalf("Failed to date post: %v", err)
+		}
+		for range p.comments {
+			if _, err := testPool.Exec(context.Background(), "INSERT INTO comments (id, content, post_id, author_id) SELECT gen_random_uuid(), 'Nice', id, author_id FROM posts WHERE id = $1", postID); err != nil {
+				t.Fatalf("Failed to comment post: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "Default order", query: "", expected: []string{"Apple", "Cherry", "Banana"}},
+		{name: "Author", query: "author=ALICE", expected: []string{"Apple", "Banana"}},
+		{name: "Unknown author", query: "author=carol", expected: []string{}},
+		{name: "Created range", query: "created_from=2024-02-01&created_to=2024-03-10", expected: []string{"Apple", "Cherry"}},
+		{name: "Created before time", query: "created_to=2024-03-10T08:00:00Z", expected: []string{"Cherry", "Banana"}},
+		{name: "Updated since", query: "updated_from=2024-03-15", expected: []string{"Apple", "Cherry"}},
+		{name: "Title", query: "sort=title", expected: []string{"Apple", "Banana", "Cherry"}},
+		{name: "Title descending", query: "sort=title&order=desc", expected: []string{"Cherry", "Banana", "Apple"}},
+		{name: "Updated oldest first", query: "sort=updated_at&order=asc", expected: []string{"Banana", "Apple", "Cherry"}},
+		{name: "Most commented", query: "sort=comment_count", expected: []string{"Banana", "Cherry", "Apple"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := listPostsPage(t, "/api/v1/posts?"+tt.query)
+
+			titles := []string{}
+			for _, p := range result.Posts {
+				titles = append(titles, p.Title)
+			}
+			if !slices.Equal(titles, tt.expected) {
+				t.Errorf("Expected posts %v, got %v", tt.expected, titles)
+			}
+		})
+	}
+
+	// Cursors continue in the order they were made in
+	first, _ := listPostsPage(t, "/api/v1/posts?sort=comment_count&page_size=2")
+	if len(first.Posts) != 2 || first.Posts[0].CommentCount != 2 || first.Posts[1].CommentCount != 1 {
+		t.Fatalf("Expected the two most commented posts first, got %v", first.Posts)
+	}
+	second, _ := listPostsPage(t, "/api/v1/posts?sort=comment_count&page_size=2&cursor="+first.NextCursor)
+	if len(second.Posts) != 1 || second.Posts[0].Title != "Apple" {
+		t.Errorf("Expected only 'Apple' on the second page, got %v", second.Posts)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts?sort=title&cursor="+first.NextCursor, nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a cursor of another order, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestListPosts_InvalidFilter(t *testing.T) {
+	cleanup(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		details []validation.Violation
+	}{
+		{
+			name:  "Unknown sort",
+			query: "sort=author_id%3BDROP%20TABLE%20posts&order=up",
+			details: []validation.Violation{
+				{Field: "sort", Code: validation.CodeInvalidFormat, Message: "must be one of created_at, updated_at, title or comment_count"},
+				{Field: "order", Code: validation.CodeInvalidFormat, Message: "must be asc or desc"},
+			},
+		},
+		{
+			name:  "Tag without a slug",
+			query: "tag=%21%21%21",
+			details: []validation.Violation{
+				{Field: "tag", Code: validation.CodeInvalidFormat, Message: "must contain a letter or digit"},
+			},
+		},
+		{
+			name:  "Malformed date",
+			query: "created_from=yesterday",
+			details: []validation.Violation{
+				{Field: "created_from", Code: validation.CodeInvalidFormat, Message: "must be a date such as 2024-01-31 or a time such as 2024-01-31T15:04:05Z"},
+			},
+		},
+		{
+			name:  "Empty range",
+			query: "updated_from=2024-02-01&updated_to=2024-01-01",
+			details: []validation.Violation{
+				{Field: "updated_to", Code: validation.CodeInvalidFormat, Message: "must be after updated_from"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/posts?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			testServer.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+
+			var response server.APIResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if !slices.Equal(response.Details, tt.details) {
+				t.Errorf("Expected details %v, got %v", tt.details, response.Details)
+			}
+		})
+	}
+}
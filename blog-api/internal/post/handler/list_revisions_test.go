@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func updatePost(t *testing.T, token, postID, title, content string) {
+	t.Helper()
+
+	body, _ := json.Marshal(post.UpdatePostRequest{Title: title, Content: content})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/posts/"+postID, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Failed to update post: %s", rec.Body.String())
+	}
+}
+
+func revisionsRequest(t *testing.T, method, token, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/api/v1/posts/"+path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListRevisions_Success(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "reviser", "reviser@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{Title: "First title", Content: "First content"})
+	updatePost(t, token, postID, "Second title", "Second content")
+	updatePost(t, token, postID, "Third title", "Third content")
+
+	rec := revisionsRequest(t, http.MethodGet, token, postID+"/revisions")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result post.RevisionListResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	revisions := response.Result.Revisions
+	if len(revisions) != 3 {
+		t.Fatalf("Expected 3 revisions, got %d", len(revisions))
+	}
+	for i, title := range []string{"Third title", "Second title", "First title"} {
+		if revisions[i].Revision != 3-i {
+			t.Errorf("Expected revision %d at %d, got %d", 3-i, i, revisions[i].Revision)
+		}
+		if revisions[i].Title != title {
+			t.Errorf("Expected title '%s' at %d, got '%s'", title, i, revisions[i].Title)
+		}
+	}
+}
+
+func TestListRevisions_Permissions(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "historyowner", "historyowner@example.com", "Str0ng-Passw0rd")
+	otherToken := registerAndGetToken(t, "historyreader", "historyreader@example.com", "Str0ng-Passw0rd")
+	moderatorToken := registerWithRole(t, "historymod", "historymod@example.com", "Str0ng-Passw0rd", policy.RoleModerator)
+	postID, _ := createPostWithStatus(t, authorToken, post.CreatePostRequest{Title: "Private history", Content: "Some content"})
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{name: "Other user", token: otherToken, expectedStatus: http.StatusForbidden},
+		{name: "Moderator", token: moderatorToken, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := revisionsRequest(t, http.MethodGet, tt.token, postID+"/revisions")
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestListRevisions_NotFound(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "nohistory", "nohistory@example.com", "Str0ng-Passw0rd")
+
+	rec := revisionsRequest(t, http.MethodGet, token, "00000000-0000-0000-0000-000000000000/revisions")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// ListTags godoc
+// @Summary      List tags
+// @Description  Get the tags of published posts with how many posts have them, from the most used
+// @Tags         posts
+// @Produce      json
+// @Success      200  {object}  server.APIResponse{message=string,result=post.TagListResponse}  "Tags retrieved successfully"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}                 "Internal server error"
+// @Router       /api/v1/tags [get]
+func (h *Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.ListTags(r.Context())
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Tags retrieved successfully",
+		Result:  result,
+	})
+}
@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func TestListTags_Success(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "tagger", "tagger@example.com", "Str0ng-Passw0rd")
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Go post", Content: "Some content", Tags: []string{"Go", "Databases"}})
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Another Go post", Content: "Some content", Tags: []string{"go"}})
+	// Drafts do not count
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Draft", Content: "Some content", Status: post.StatusDraft, Tags: []string{"Databases", "Secret"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result post.TagListResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	// The tag keeps the name it was first written with
+	expected := []post.TagItem{
+		{Slug: "go", Name: "Go", PostCount: 2},
+		{Slug: "databases", Name: "Databases", PostCount: 1},
+	}
+	if !slices.Equal(response.Result.Tags, expected) {
+		t.Errorf("Expected tags %v, got %v", expected, response.Result.Tags)
+	}
+}
+
+func TestListTags_Empty(t *testing.T) {
+	cleanup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result map[string]any `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if tags, ok := response.Result["tags"].([]any); !ok || len(tags) != 0 {
+		t.Errorf("Expected an empty list of tags, got %v", response.Result["tags"])
+	}
+}
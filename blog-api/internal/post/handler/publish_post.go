@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// PublishPost godoc
+// @Summary      Publish a post
+// @Description  Publish a draft or archived post right away, or schedule it when publish_at is given (only the author can publish). The body is optional.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postId   path      string                   true   "Post ID"
+// @Param        request  body      post.PublishPostRequest  false  "When to publish"
+// @Success      200      {object}  server.APIResponse{message=string,result=post.PostStatusResponse}  "Post published successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}                    "Invalid input"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}                    "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}                    "Forbidden - not the author"
+// @Failure      404      {object}  server.APIResponse{message=string,error=string}                    "Post not found"
+// @Failure      409      {object}  server.APIResponse{message=string,error=string}                    "Post is already published"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}                    "Internal server error"
+// @Router       /api/v1/posts/{postId}/publish [post]
+func (h *Handler) PublishPost(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+	actor := policy.Actor{UserID: userID, Role: claims.Role}
+
+	postID, err := uuid.Parse(r.PathValue("postId"))
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid post ID", nil)
+		return
+	}
+
+	var req post.PublishPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	result, err := h.service.Publish(r.Context(), postID, actor, req)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	message := "Post published successfully"
+	if result.Status == post.StatusScheduled {
+		message = "Post scheduled successfully"
+	}
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: message,
+		Result:  result,
+	})
+}
@@ -0,0 +1,212 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// createPostWithStatus creates a post and returns its ID and slug.
+func createPostWithStatus(t *testing.T, token string, request post.CreatePostRequest) (string, string) {
+	t.Helper()
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create post: %s", rec.Body.String())
+	}
+
+	var response struct {
+		Result post.PostID `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	var slug string
+	if err := testPool.QueryRow(context.Background(), "SELECT slug FROM posts WHERE id = $1", response.Result.ID).Scan(&slug); err != nil {
+		t.Fatalf("Failed to get slug: %v", err)
+	}
+	return response.Result.ID, slug
+}
+
+func changePostStatus(t *testing.T, token, postID, action string, request *post.PublishPostRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body []byte
+	if request != nil {
+		body, _ = json.Marshal(request)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts/"+postID+"/"+action, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func postStatus(t *testing.T, rec *httptest.ResponseRecorder) post.PostStatusResponse {
+	t.Helper()
+
+	var response struct {
+		Result post.PostStatusResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result
+}
+
+// getPost gets a post by slug as the user of token, or anonymously when
+// token is empty.
+func getPost(t *testing.T, token, slug string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/"+slug, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPublishPost_Success(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "publisher", "publisher@example.com", "Str0ng-Passw0rd")
+	postID, slug := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Draft to publish",
+		Content: "Some content",
+		Status:  post.StatusDraft,
+	})
+
+	if rec := getPost(t, "", slug); rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected draft to be hidden, got %d", rec.Code)
+	}
+
+	rec := changePostStatus(t, token, postID, "publish", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	result := postStatus(t, rec)
+	if result.Status != post.StatusPublished {
+		t.Errorf("Expected status '%s', got '%s'", post.StatusPublished, result.Status)
+	}
+	if result.PublishedAt == nil {
+		t.Error("Expected published_at to be set")
+	}
+
+	if rec := getPost(t, "", slug); rec.Code != http.StatusOK {
+		t.Errorf("Expected published post to be visible, got %d", rec.Code)
+	}
+
+	// Publishing twice is a conflict
+	if rec := changePostStatus(t, token, postID, "publish", nil); rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
+
+func TestPublishPost_Schedule(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "scheduler", "scheduler@example.com", "Str0ng-Passw0rd")
+	postID, slug := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Post for later",
+		Content: "Some content",
+		Status:  post.StatusDraft,
+	})
+
+	publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	rec := changePostStatus(t, token, postID, "publish", &post.PublishPostRequest{PublishAt: &publishAt})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	result := postStatus(t, rec)
+	if result.Status != post.StatusScheduled {
+		t.Errorf("Expected status '%s', got '%s'", post.StatusScheduled, result.Status)
+	}
+	if result.PublishedAt == nil || !result.PublishedAt.Equal(publishAt) {
+		t.Errorf("Expected published_at %v, got %v", publishAt, result.PublishedAt)
+	}
+
+	// Nothing is due yet
+	testPostService.PublishScheduled(context.Background())
+	if rec := getPost(t, "", slug); rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected scheduled post to be hidden, got %d", rec.Code)
+	}
+
+	if _, err := testPool.Exec(context.Background(), "UPDATE posts SET published_at = NOW() - INTERVAL '1 minute' WHERE id = $1", postID); err != nil {
+		t.Fatalf("Failed to move the schedule: %v", err)
+	}
+	testPostService.PublishScheduled(context.Background())
+
+	rec = getPost(t, "", slug)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected post to be published once due, got %d", rec.Code)
+	}
+	var response struct {
+		Result post.PostItem `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Result.Status != post.StatusPublished {
+		t.Errorf("Expected status '%s', got '%s'", post.StatusPublished, response.Result.Status)
+	}
+}
+
+func TestPublishPost_PublishAtInPast(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "pastpublisher", "pastpublisher@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Post for the past",
+		Content: "Some content",
+		Status:  post.StatusDraft,
+	})
+
+	publishAt := time.Now().Add(-time.Hour)
+	rec := changePostStatus(t, token, postID, "publish", &post.PublishPostRequest{PublishAt: &publishAt})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
+
+func TestPublishPost_NotAuthor(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "draftauthor", "draftauthor@example.com", "Str0ng-Passw0rd")
+	otherToken := registerAndGetToken(t, "notdraftauthor", "notdraftauthor@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, authorToken, post.CreatePostRequest{
+		Title:   "Someone else's draft",
+		Content: "Some content",
+		Status:  post.StatusDraft,
+	})
+
+	if rec := changePostStatus(t, otherToken, postID, "publish", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+}
+
+func TestPublishPost_NotFound(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "nopublisher", "nopublisher@example.com", "Str0ng-Passw0rd")
+
+	rec := changePostStatus(t, token, "00000000-0000-0000-0000-000000000000", "publish", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func TestRestoreRevision_Success(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "restorer", "restorer@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{Title: "Good title", Content: "Good content"})
+	updatePost(t, token, postID, "Bad title", "Accidentally erased")
+
+	rec := revisionsRequest(t, http.MethodPost, token, postID+"/revisions/1/restore")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	rec = revisionsRequest(t, http.MethodGet, token, postID+"/revisions")
+	var listResponse struct {
+		Result post.RevisionListResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listResponse); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	// The restore is a revision of its own, so it can be undone too
+	if len(listResponse.Result.Revisions) != 3 {
+		t.Fatalf("Expected 3 revisions, got %d", len(listResponse.Result.Revisions))
+	}
+	if latest := listResponse.Result.Revisions[0]; latest.Revision != 3 || latest.Title != "Good title" {
+		t.Errorf("Expected revision 3 titled 'Good title', got %d '%s'", latest.Revision, latest.Title)
+	}
+
+	var slug string
+	if err := testPool.QueryRow(context.Background(), "SELECT slug FROM posts WHERE id = $1", postID).Scan(&slug); err != nil {
+		t.Fatalf("Failed to get slug: %v", err)
+	}
+	rec = getPost(t, "", slug)
+	var postResponse struct {
+		Result post.PostItem `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &postResponse); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if postResponse.Result.Title != "Good title" || postResponse.Result.Content != "Good content" {
+		t.Errorf("Expected the restored post, got '%s' '%s'", postResponse.Result.Title, postResponse.Result.Content)
+	}
+}
+
+func TestRestoreRevision_NotAuthor(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "keepsversion", "keepsversion@example.com", "Str0ng-Passw0rd")
+	moderatorToken := registerWithRole(t, "restoremod", "restoremod@example.com", "Str0ng-Passw0rd", policy.RoleModerator)
+	postID, _ := createPostWithStatus(t, authorToken, post.CreatePostRequest{Title: "Original", Content: "Original content"})
+	updatePost(t, authorToken, postID, "Edited", "Edited content")
+
+	// Moderators can read the history but not edit the post
+	rec := revisionsRequest(t, http.MethodPost, moderatorToken, postID+"/revisions/1/restore")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+}
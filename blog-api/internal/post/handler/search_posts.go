@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// SearchPosts godoc
+// @Summary      Search posts
+// @Description  Full-text search through the titles and content of the published blog posts, from the best match. Words in double quotes have to appear next to each other and a word ending in `*` matches any word it starts. Authenticated users also search their own unpublished posts.
+// @Tags         posts
+// @Produce      json
+// @Param        q         query     string  true   "Search, e.g. \"open source\" data*"
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200       {object}  server.APIResponse{message=string,result=post.PostSearchResponse}  "Posts found"
+// @Failure      400       {object}  server.APIResponse{message=string,error=string}                    "Invalid cursor"
+// @Failure      401       {object}  server.APIResponse{message=string,error=string}                    "Invalid token"
+// @Failure      422       {object}  server.APIResponse{message=string,error=string}                    "Invalid search, or a cursor"
+// @Failure      500       {object}  server.APIResponse{message=string,error=string}                    "Internal server error"
+// @Router       /api/v1/posts/search [get]
+func (h *Handler) SearchPosts(w http.ResponseWriter, r *http.Request) {
+	p, err := pagination.FromRequest(r)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	result, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), viewerID(r), p)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Posts found",
+		Result:  result,
+	})
+}
@@ -0,0 +1,158 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
+)
+
+func searchPosts(t *testing.T, token, q string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/search?q="+url.QueryEscape(q), nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSearchPosts_Success(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "searcher", "searcher@example.com", "Str0ng-Passw0rd")
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Gardening basics", Content: "Water the database of plants <daily>."})
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Database indexing", Content: "Indexes make open source databases fast."})
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Source of truth", Content: "Open the box, the source is inside."})
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Database draft", Content: "Not yet.", Status: post.StatusDraft})
+
+	tests := []struct {
+		name     string
+		q        string
+		token    string
+		expected []string
+	}{
+		{name: "Title ranks first", q: "database", expected: []string{"Database indexing", "Gardening basics"}},
+		{name: "Own drafts", q: "database", token: token, expected: []string{"Database draft", "Database indexing", "Gardening basics"}},
+		{name: "Phrase", q: `"open source"`, expected: []string{"Database indexing"}},
+		{name: "Words anywhere", q: "open source", expected: []string{"Source of truth", "Database indexing"}},
+		{name: "Prefix", q: "index*", expected: []string{"Database indexing"}},
+		{name: "Operators are words", q: "database & !plants", expected: []string{"Gardening basics"}},
+		{name: "No match", q: "rust", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := searchPosts(t, tt.token, tt.q)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var response struct {
+				Result post.PostSearchResponse `json:"result"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+
+			titles := []string{}
+			for _, p := range response.Result.Posts {
+				titles = append(titles, p.Title)
+			}
+			if !slices.Equal(titles, tt.expected) {
+				t.Errorf("Expected posts %v, got %v", tt.expected, titles)
+			}
+			if response.Result.TotalCount != len(tt.expected) {
+				t.Errorf("Expected total count %d, got %d", len(tt.expected), response.Result.TotalCount)
+			}
+		})
+	}
+}
+
+func TestSearchPosts_Snippet(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "snippets", "snippets@example.com", "Str0ng-Passw0rd")
+	createPostWithStatus(t, token, post.CreatePostRequest{Title: "Markup", Content: "Tom & Jerry use <b>tags</b> with care."})
+
+	rec := searchPosts(t, "", "tags")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result post.PostSearchResponse `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Result.Posts) != 1 {
+		t.Fatalf("Expected 1 post, got %d", len(response.Result.Posts))
+	}
+
+	// The content is escaped, only the highlights are HTML
+	snippet := response.Result.Posts[0].Snippet
+	if !strings.Contains(snippet, "Tom &amp; Jerry use") || !strings.Contains(snippet, "<mark>tags</mark>") {
+		t.Errorf("Expected escaped snippet with highlighted match, got '%s'", snippet)
+	}
+}
+
+func TestSearchPosts_InvalidSearch(t *testing.T) {
+	cleanup(t)
+
+	for _, q := range []string{"", `" * "`, strings.Repeat("a", 201)} {
+		rec := searchPosts(t, "", q)
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("Expected status %d for '%s', got %d. Body: %s", http.StatusUnprocessableEntity, q, rec.Code, rec.Body.String())
+		}
+
+		var response server.APIResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Error != "INVALID_INPUT" {
+			t.Errorf("Expected error 'INVALID_INPUT', got '%s'", response.Error)
+		}
+	}
+}
+
+func TestSearchPosts_Cursor(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "cursorsearcher", "cursorsearcher@example.com", "Str0ng-Passw0rd")
+	createPost(t, token, "Gardening basics")
+	createPost(t, token, "Gardening advanced")
+
+	// A cursor of the listing would silently restart the ranked results
+	listing, _ := listPostsPage(t, "/api/v1/posts?page_size=1")
+	if listing.NextCursor == "" {
+		t.Fatal("Expected a next cursor")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/search?q=gardening&cursor="+listing.NextCursor, nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expected := []validation.Violation{
+		{Field: "cursor", Code: validation.CodeInvalidFormat, Message: "is not supported, search results are paginated with page"},
+	}
+	if !slices.Equal(response.Details, expected) {
+		t.Errorf("Expected details %v, got %v", expected, response.Details)
+	}
+}
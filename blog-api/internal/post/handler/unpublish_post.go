@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// UnpublishPost godoc
+// @Summary      Unpublish a post
+// @Description  Archive a published post, or turn a scheduled post back into a draft (only the author can unpublish)
+// @Tags         posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postId  path      string  true  "Post ID"
+// @Success      200     {object}  server.APIResponse{message=string,result=post.PostStatusResponse}  "Post unpublished successfully"
+// @Failure      400     {object}  server.APIResponse{message=string,error=string}                    "Invalid post ID"
+// @Failure      401     {object}  server.APIResponse{message=string,error=string}                    "Unauthorized"
+// @Failure      403     {object}  server.APIResponse{message=string,error=string}                    "Forbidden - not the author"
+// @Failure      404     {object}  server.APIResponse{message=string,error=string}                    "Post not found"
+// @Failure      409     {object}  server.APIResponse{message=string,error=string}                    "Post is neither published nor scheduled"
+// @Failure      500     {object}  server.APIResponse{message=string,error=string}                    "Internal server error"
+// @Router       /api/v1/posts/{postId}/unpublish [post]
+func (h *Handler) UnpublishPost(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+	actor := policy.Actor{UserID: userID, Role: claims.Role}
+
+	postID, err := uuid.Parse(r.PathValue("postId"))
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid post ID", nil)
+		return
+	}
+
+	result, err := h.service.Unpublish(r.Context(), postID, actor)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Post unpublished successfully",
+		Result:  result,
+	})
+}
@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func TestUnpublishPost_Published(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "archiver", "archiver@example.com", "Str0ng-Passw0rd")
+	postID, slug := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Post to archive",
+		Content: "Some content",
+	})
+
+	rec := changePostStatus(t, token, postID, "unpublish", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	result := postStatus(t, rec)
+	if result.Status != post.StatusArchived {
+		t.Errorf("Expected status '%s', got '%s'", post.StatusArchived, result.Status)
+	}
+	if result.PublishedAt == nil {
+		t.Error("Expected archived post to keep published_at")
+	}
+
+	if rec := getPost(t, "", slug); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected archived post to be hidden, got %d", rec.Code)
+	}
+	if rec := getPost(t, token, slug); rec.Code != http.StatusOK {
+		t.Errorf("Expected the author to still see the post, got %d", rec.Code)
+	}
+}
+
+func TestUnpublishPost_Scheduled(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "unscheduler", "unscheduler@example.com", "Str0ng-Passw0rd")
+	publishAt := time.Now().Add(time.Hour)
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:     "Post no longer planned",
+		Content:   "Some content",
+		Status:    post.StatusScheduled,
+		PublishAt: &publishAt,
+	})
+
+	rec := changePostStatus(t, token, postID, "unpublish", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	result := postStatus(t, rec)
+	if result.Status != post.StatusDraft {
+		t.Errorf("Expected status '%s', got '%s'", post.StatusDraft, result.Status)
+	}
+	if result.PublishedAt != nil {
+		t.Errorf("Expected published_at to be cleared, got %v", result.PublishedAt)
+	}
+}
+
+func TestUnpublishPost_Draft(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "draftonly", "draftonly@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, token, post.CreatePostRequest{
+		Title:   "Never published",
+		Content: "Some content",
+		Status:  post.StatusDraft,
+	})
+
+	if rec := changePostStatus(t, token, postID, "unpublish", nil); rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
+
+func TestUnpublishPost_NotAuthor(t *testing.T) {
+	cleanup(t)
+
+	authorToken := registerAndGetToken(t, "keeppublished", "keeppublished@example.com", "Str0ng-Passw0rd")
+	otherToken := registerAndGetToken(t, "wantsunpublish", "wantsunpublish@example.com", "Str0ng-Passw0rd")
+	postID, _ := createPostWithStatus(t, authorToken, post.CreatePostRequest{
+		Title:   "Stays published",
+		Content: "Some content",
+	})
+
+	if rec := changePostStatus(t, otherToken, postID, "unpublish", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 )
@@ -29,32 +30,33 @@ import (
 func (h *Handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	claims, ok := server.GetUserClaims(r.Context())
 	if !ok {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
 		return
 	}
 
-	authorID, err := uuid.Parse(claims.UserID)
+	userID, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusUnauthorized, "Invalid user ID", nil)
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
 		return
 	}
+	actor := policy.Actor{UserID: userID, Role: claims.Role}
 
 	postIDStr := r.PathValue("postId")
 	postID, err := uuid.Parse(postIDStr)
 	if err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid post ID", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid post ID", nil)
 		return
 	}
 
 	var req post.UpdatePostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
 		return
 	}
 
-	p, err := h.service.Update(r.Context(), postID, authorID, req)
+	p, err := h.service.Update(r.Context(), postID, actor, req)
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
@@ -14,7 +15,7 @@ import (
 func TestUpdatePost_Success(t *testing.T) {
 	cleanup(t)
 
-	token := registerAndGetToken(t, "postauthor", "author@example.com", "password123")
+	token := registerAndGetToken(t, "postauthor", "author@example.com", "Str0ng-Passw0rd")
 
 	// Create a post
 	createReq := post.CreatePostRequest{
@@ -66,7 +67,7 @@ func TestUpdatePost_NotAuthor(t *testing.T) {
 	cleanup(t)
 
 	// User 1 creates a post
-	token1 := registerAndGetToken(t, "author1", "author1@example.com", "password123")
+	token1 := registerAndGetToken(t, "author1", "author1@example.com", "Str0ng-Passw0rd")
 
 	createReq := post.CreatePostRequest{
 		Title:   "Author1 Post",
@@ -87,7 +88,7 @@ func TestUpdatePost_NotAuthor(t *testing.T) {
 	postID := result["id"].(string)
 
 	// User 2 tries to update
-	token2 := registerAndGetToken(t, "author2", "author2@example.com", "password123")
+	token2 := registerAndGetToken(t, "author2", "author2@example.com", "Str0ng-Passw0rd")
 
 	updateReq := post.UpdatePostRequest{
 		Title:   "Hacked Title",
@@ -124,3 +125,45 @@ func TestUpdatePost_Unauthorized(t *testing.T) {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
 	}
 }
+
+func TestUpdatePost_Tags(t *testing.T) {
+	cleanup(t)
+
+	token := registerAndGetToken(t, "tagupdater", "tagupdater@example.com", "Str0ng-Passw0rd")
+	postID, slug := createPostWithStatus(t, token, post.CreatePostRequest{Title: "Tagged post", Content: "Some content", Tags: []string{"Go"}})
+
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []post.Tag
+	}{
+		{name: "Replaced", tags: []string{"Databases", "SQL"}, expected: []post.Tag{{Slug: "databases", Name: "Databases"}, {Slug: "sql", Name: "SQL"}}},
+		{name: "Kept when omitted", tags: nil, expected: []post.Tag{{Slug: "databases", Name: "Databases"}, {Slug: "sql", Name: "SQL"}}},
+		{name: "Cleared", tags: []string{}, expected: []post.Tag{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(post.UpdatePostRequest{Title: "Tagged post", Content: "Some content", Tags: tt.tags})
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/posts/"+postID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			testServer.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var response struct {
+				Result post.PostItem `json:"result"`
+			}
+			if err := json.Unmarshal(getPost(t, "", slug).Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if !slices.Equal(response.Result.Tags, tt.expected) {
+				t.Errorf("Expected tags %v, got %v", tt.expected, response.Result.Tags)
+			}
+		})
+	}
+}
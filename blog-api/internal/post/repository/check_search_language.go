@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+)
+
+// CheckSearchLanguage returns an error when Postgres has no text search
+// configuration with the given name.
+func (r *Repository) CheckSearchLanguage(ctx context.Context, language string) error {
+	_, err := r.db.Exec(ctx, "SELECT $1::regconfig", language)
+	return err
+}
@@ -3,30 +3,68 @@ package repository
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (r *Repository) Create(ctx context.Context, p *post.Post) error {
-	query := `
+// Create stores a new post together with its first revision and its tags.
+func (r *Repository) Create(ctx context.Context, p *post.Post, tags []post.Tag) error {
+	createPostQuery := `
 		INSERT INTO posts (
 			id,
 			title,
 			slug,
 			content,
+			content_html,
 			author_id,
+			status,
+			published_at,
 			created_at,
-			updated_at
+			updated_at,
+			search_language
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	createRevisionQuery := `
+		INSERT INTO post_revisions (
+			post_id,
+			revision,
+			title,
+			content,
+			created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, 1, $2, $3, $4)
 	`
-	_, err := r.db.Exec(ctx, query,
-		p.ID,
-		p.Title,
-		p.Slug,
-		p.Content,
-		p.AuthorID,
-		p.CreatedAt,
-		p.UpdatedAt,
-	)
-	return err
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, createPostQuery,
+			p.ID,
+			p.Title,
+			p.Slug,
+			p.Content,
+			p.ContentHTML,
+			p.AuthorID,
+			p.Status,
+			p.PublishedAt,
+			p.CreatedAt,
+			p.UpdatedAt,
+			p.SearchLanguage,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, createRevisionQuery,
+			p.ID,
+			p.Title,
+			p.Content,
+			p.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		return setPostTags(ctx, tx, p.ID, tags)
+	})
 }
@@ -2,12 +2,57 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (r *Repository) FindAll(ctx context.Context, page, pageSize int) ([]post.PostWithAuthor, int, error) {
-	offset := (page - 1) * pageSize
+// commentCount counts the comments of the post p, it is backed by the index
+// on the post ID of comments.
+const commentCount = "(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.deleted_at IS NULL)"
+
+// sortColumns are the columns listings can be sorted by, the only ones put
+// in the ORDER BY.
+var sortColumns = map[post.SortField]string{
+	post.SortCreatedAt:    "p.created_at",
+	post.SortUpdatedAt:    "p.updated_at",
+	post.SortTitle:        "p.title",
+	post.SortCommentCount: commentCount,
+}
+
+// FindAll returns up to p.Limit() posts in the filter's order, rows before a
+// cursor come in the opposite order.
+func (r *Repository) FindAll(ctx context.Context, filter post.ListFilter, p pagination.Request) ([]post.PostWithAuthor, error) {
+	conditions, args := listConditions(filter)
+
+	column, ok := sortColumns[filter.Sort.Field]
+	if !ok {
+		column = sortColumns[post.SortCreatedAt]
+	}
+	asc := filter.Sort.Asc
+	if p.Cursor.Before {
+		asc = !asc
+	}
+	order, op := "DESC", "<"
+	if asc {
+		order, op = "ASC", ">"
+	}
+
+	if !p.Cursor.IsZero() {
+		key, err := filter.Sort.ParseCursorKey(p.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, key, p.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, p.id) %s ($%d, $%d)", column, op, len(args)-1, len(args)))
+	}
+	args = append(args, p.Limit(), p.Offset())
 
 	query := `
 		SELECT
@@ -16,24 +61,25 @@ func (r *Repository) FindAll(ctx context.Context, page, pageSize int) ([]post.Po
 			p.slug,
 			p.content,
 			p.author_id,
+			p.status,
+			p.published_at,
 			p.created_at,
 			p.updated_at,
-			u.username,
-			COUNT(*) OVER() AS total_count
+			CASE WHEN u.deleted_at IS NULL THEN u.username ELSE 'deleted user' END AS username,
+			` + commentCount + ` AS comment_count
 		FROM posts p
 		JOIN users u ON p.author_id = u.id
 		WHERE
-			p.deleted_at IS NULL
-		ORDER BY p.created_at DESC
-		LIMIT $1 OFFSET $2
+			` + strings.Join(conditions, " AND ") + `
+		ORDER BY ` + column + ` ` + order + `, p.id ` + order + `
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(args)-1, len(args)) + `
 	`
-	rows, err := r.db.Query(ctx, query, pageSize, offset)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	var totalCount int
 	var posts []post.PostWithAuthor
 	for rows.Next() {
 		var p post.PostWithAuthor
@@ -43,15 +89,71 @@ func (r *Repository) FindAll(ctx context.Context, page, pageSize int) ([]post.Po
 			&p.Slug,
 			&p.Content,
 			&p.AuthorID,
+			&p.Status,
+			&p.PublishedAt,
 			&p.CreatedAt,
 			&p.UpdatedAt,
 			&p.AuthorUsername,
-			&totalCount,
+			&p.CommentCount,
 		); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		posts = append(posts, p)
 	}
 
-	return posts, totalCount, nil
+	return posts, rows.Err()
+}
+
+// CountAll returns the number of posts FindAll lists over all pages.
+func (r *Repository) CountAll(ctx context.Context, filter post.ListFilter) (int, error) {
+	conditions, args := listConditions(filter)
+
+	query := `
+		SELECT COUNT(*)
+		FROM posts p
+		JOIN users u ON p.author_id = u.id
+		WHERE
+			` + strings.Join(conditions, " AND ") + `
+	`
+	var count int
+	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// listConditions returns the WHERE conditions of a listing and their
+// arguments, numbered from $1.
+func listConditions(filter post.ListFilter) ([]string, []any) {
+	conditions := []string{"p.deleted_at IS NULL"}
+	args := []any{filter.ViewerID}
+	conditions = append(conditions, "(p.status = 'published' OR p.author_id = $1)")
+
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM post_tags pt WHERE pt.post_id = p.id AND pt.tag_slug = $%d)", len(args)))
+	}
+	if filter.AuthorID != uuid.Nil {
+		args = append(args, filter.AuthorID)
+		conditions = append(conditions, fmt.Sprintf("p.author_id = $%d", len(args)))
+	}
+	if filter.Author != "" {
+		args = append(args, normalize.Identifier(filter.Author))
+		conditions = append(conditions, fmt.Sprintf("(u.username_normalized = $%d AND u.deleted_at IS NULL)", len(args)))
+	}
+
+	ranges := []struct {
+		condition string
+		value     time.Time
+	}{
+		{"p.created_at >= $%d", filter.CreatedFrom},
+		{"p.created_at < $%d", filter.CreatedTo},
+		{"p.updated_at >= $%d", filter.UpdatedFrom},
+		{"p.updated_at < $%d", filter.UpdatedTo},
+	}
+	for _, r := range ranges {
+		if !r.value.IsZero() {
+			args = append(args, r.value)
+			conditions = append(conditions, fmt.Sprintf(r.condition, len(args)))
+		}
+	}
+	return conditions, args
 }
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+)
+
+// FindAuthorIDByUsername returns the ID of the user with the given username,
+// ignoring case, or uuid.Nil when there is no such user.
+func (r *Repository) FindAuthorIDByUsername(ctx context.Context, username string) (uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE
+			username_normalized = $1
+			AND deleted_at IS NULL
+	`
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query, normalize.Identifier(username)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
@@ -18,6 +18,8 @@ func (r *Repository) FindByID(ctx context.Context, id uuid.UUID) (post.Post, err
 			slug,
 			content,
 			author_id,
+			status,
+			published_at,
 			created_at,
 			updated_at
 		FROM posts
@@ -32,6 +34,8 @@ func (r *Repository) FindByID(ctx context.Context, id uuid.UUID) (post.Post, err
 		&p.Slug,
 		&p.Content,
 		&p.AuthorID,
+		&p.Status,
+		&p.PublishedAt,
 		&p.CreatedAt,
 		&p.UpdatedAt,
 	)
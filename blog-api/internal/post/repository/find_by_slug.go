@@ -4,38 +4,50 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (r *Repository) FindBySlugWithAuthor(ctx context.Context, slug string) (post.PostWithAuthor, error) {
+// FindBySlugWithAuthor returns the post with the given slug, or a zero post
+// when there is none or it is not published and viewerID is not its author.
+func (r *Repository) FindBySlugWithAuthor(ctx context.Context, slug string, viewerID uuid.UUID) (post.PostWithAuthor, error) {
 	query := `
 		SELECT
 			p.id,
 			p.title,
 			p.slug,
 			p.content,
+			COALESCE(p.content_html, ''),
 			p.author_id,
+			p.status,
+			p.published_at,
 			p.created_at,
 			p.updated_at,
-			u.username
+			CASE WHEN u.deleted_at IS NULL THEN u.username ELSE 'deleted user' END AS username,
+			` + commentCount + ` AS comment_count
 		FROM posts p
 		JOIN users u ON p.author_id = u.id
 		WHERE
 			p.slug = $1
 			AND p.deleted_at IS NULL
+			AND (p.status = 'published' OR p.author_id = $2)
 	`
 	p := post.PostWithAuthor{}
-	err := r.db.QueryRow(ctx, query, slug).Scan(
+	err := r.db.QueryRow(ctx, query, slug, viewerID).Scan(
 		&p.ID,
 		&p.Title,
 		&p.Slug,
 		&p.Content,
+		&p.ContentHTML,
 		&p.AuthorID,
+		&p.Status,
+		&p.PublishedAt,
 		&p.CreatedAt,
 		&p.UpdatedAt,
 		&p.AuthorUsername,
+		&p.CommentCount,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return post.PostWithAuthor{}, nil
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// FindRevision returns the given revision of a post, or a zero revision when
+// there is no such revision.
+func (r *Repository) FindRevision(ctx context.Context, postID uuid.UUID, revision int) (post.Revision, error) {
+	query := `
+		SELECT
+			post_id,
+			revision,
+			title,
+			content,
+			created_at
+		FROM post_revisions
+		WHERE
+			post_id = $1
+			AND revision = $2
+	`
+	rev := post.Revision{}
+	err := r.db.QueryRow(ctx, query, postID, revision).Scan(
+		&rev.PostID,
+		&rev.Revision,
+		&rev.Title,
+		&rev.Content,
+		&rev.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return post.Revision{}, nil
+	}
+	if err != nil {
+		return post.Revision{}, err
+	}
+	return rev, nil
+}
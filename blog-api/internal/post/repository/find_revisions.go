@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// FindRevisions returns the revisions of a post from the latest, without
+// their content.
+func (r *Repository) FindRevisions(ctx context.Context, postID uuid.UUID) ([]post.Revision, error) {
+	query := `
+		SELECT
+			post_id,
+			revision,
+			title,
+			created_at
+		FROM post_revisions
+		WHERE
+			post_id = $1
+		ORDER BY revision DESC
+	`
+	rows, err := r.db.Query(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []post.Revision
+	for rows.Next() {
+		var rev post.Revision
+		if err := rows.Scan(
+			&rev.PostID,
+			&rev.Revision,
+			&rev.Title,
+			&rev.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
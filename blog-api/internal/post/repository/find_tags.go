@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// FindTags returns the tags of published posts with how many there are,
+// from the most used.
+func (r *Repository) FindTags(ctx context.Context) ([]post.TagItem, error) {
+	query := `
+		SELECT
+			t.slug,
+			t.name,
+			COUNT(*) AS post_count
+		FROM tags t
+		JOIN post_tags pt ON pt.tag_slug = t.slug
+		JOIN posts p ON pt.post_id = p.id
+		WHERE
+			p.status = 'published'
+			AND p.deleted_at IS NULL
+		GROUP BY t.slug, t.name
+		ORDER BY post_count DESC, t.slug
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []post.TagItem
+	for rows.Next() {
+		var tag post.TagItem
+		if err := rows.Scan(
+			&tag.Slug,
+			&tag.Name,
+			&tag.PostCount,
+		); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// FindTagsByPostIDs returns the tags of each of the posts, sorted by slug,
+// in one query for a whole page of posts.
+func (r *Repository) FindTagsByPostIDs(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID][]post.Tag, error) {
+	query := `
+		SELECT
+			pt.post_id,
+			t.slug,
+			t.name
+		FROM post_tags pt
+		JOIN tags t ON pt.tag_slug = t.slug
+		WHERE
+			pt.post_id = ANY($1)
+		ORDER BY t.slug
+	`
+	rows, err := r.db.Query(ctx, query, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := map[uuid.UUID][]post.Tag{}
+	for rows.Next() {
+		var postID uuid.UUID
+		var tag post.Tag
+		if err := rows.Scan(
+			&postID,
+			&tag.Slug,
+			&tag.Name,
+		); err != nil {
+			return nil, err
+		}
+		tags[postID] = append(tags[postID], tag)
+	}
+
+	return tags, rows.Err()
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+)
+
+// PublishScheduled publishes the scheduled posts whose time has come and
+// returns how many there were.
+func (r *Repository) PublishScheduled(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE posts SET
+			status = 'published'
+		WHERE
+			status = 'scheduled'
+			AND published_at <= NOW()
+			AND deleted_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
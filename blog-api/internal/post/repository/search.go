@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// headlineOptions keeps the headlines to a few fragments around the matches
+// instead of the whole content.
+var headlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=30, MinWords=15, FragmentDelimiter=" ... "`,
+	post.HeadlineStartSel,
+	post.HeadlineStopSel,
+)
+
+// Search returns the posts matching tsQuery, a tsquery in the given text
+// search configuration, from the best match. Like FindAll it only returns
+// the unpublished posts of viewerID.
+func (r *Repository) Search(ctx context.Context, language, tsQuery string, viewerID uuid.UUID, page, pageSize int) ([]post.SearchResult, int, error) {
+	offset := (page - 1) * pageSize
+
+	// Headlines are costly, so they are only made for the posts of the page
+	query := `
+		SELECT
+			m.id,
+			m.title,
+			m.slug,
+			m.content,
+			m.author_id,
+			m.status,
+			m.published_at,
+			m.created_at,
+			m.updated_at,
+			m.username,
+			m.comment_count,
+			m.rank,
+			ts_headline($3::regconfig, m.content, to_tsquery($3::regconfig, $4), $6) AS headline,
+			m.total_count
+		FROM (
+			SELECT
+				p.id,
+				p.title,
+				p.slug,
+				p.content,
+				p.author_id,
+				p.status,
+				p.published_at,
+				p.created_at,
+				p.updated_at,
+				CASE WHEN u.deleted_at IS NULL THEN u.username ELSE 'deleted user' END AS username,
+				` + commentCount + ` AS comment_count,
+				ts_rank(p.search_vector, q.query) AS rank,
+				COUNT(*) OVER() AS total_count
+			FROM posts p
+			JOIN users u ON p.author_id = u.id
+			CROSS JOIN to_tsquery($3::regconfig, $4) AS q(query)
+			WHERE
+				p.search_vector @@ q.query
+				AND p.deleted_at IS NULL
+				AND (p.status = 'published' OR p.author_id = $5)
+			ORDER BY rank DESC, p.created_at DESC
+			LIMIT $1 OFFSET $2
+		) m
+		ORDER BY m.rank DESC, m.created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, pageSize, offset, language, tsQuery, viewerID, headlineOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var totalCount int
+	var results []post.SearchResult
+	for rows.Next() {
+		var res post.SearchResult
+		if err := rows.Scan(
+			&res.ID,
+			&res.Title,
+			&res.Slug,
+			&res.Content,
+			&res.AuthorID,
+			&res.Status,
+			&res.PublishedAt,
+			&res.CreatedAt,
+			&res.UpdatedAt,
+			&res.AuthorUsername,
+			&res.CommentCount,
+			&res.Rank,
+			&res.Headline,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, res)
+	}
+
+	return results, totalCount, rows.Err()
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// setPostTags replaces the tags of a post, creating the tags that do not
+// exist yet.
+func setPostTags(ctx context.Context, tx pgx.Tx, postID uuid.UUID, tags []post.Tag) error {
+	deleteQuery := `
+		DELETE FROM post_tags
+		WHERE
+			post_id = $1
+	`
+	// Tags that exist keep the name they were first written with
+	createTagsQuery := `
+		INSERT INTO tags (
+			slug,
+			name
+		)
+		SELECT * FROM UNNEST($1::TEXT[], $2::TEXT[])
+		ON CONFLICT (slug) DO NOTHING
+	`
+	linkQuery := `
+		INSERT INTO post_tags (
+			post_id,
+			tag_slug
+		)
+		SELECT $1, UNNEST($2::TEXT[])
+	`
+
+	if _, err := tx.Exec(ctx, deleteQuery, postID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	slugs := make([]string, len(tags))
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+		names[i] = tag.Name
+	}
+
+	if _, err := tx.Exec(ctx, createTagsQuery, slugs, names); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, linkQuery, postID, slugs)
+	return err
+}
@@ -3,25 +3,65 @@ package repository
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (r *Repository) Update(ctx context.Context, p post.Post) error {
-	query := `
+// Update changes the title and content of a post and appends them as its
+// next revision. The tags of the post are replaced unless tags is nil.
+func (r *Repository) Update(ctx context.Context, p post.Post, tags []post.Tag) error {
+	updatePostQuery := `
 		UPDATE posts SET
 			title = $1,
 			slug = $2,
 			content = $3,
+			content_html = $4,
+			search_language = $5,
 			updated_at = NOW()
 		WHERE
-			id = $4
+			id = $6
 			AND deleted_at IS NULL
 	`
-	_, err := r.db.Exec(ctx, query,
-		p.Title,
-		p.Slug,
-		p.Content,
-		p.ID,
-	)
-	return err
+	// The update above locks the post, so concurrent updates of the same
+	// post number their revisions one after the other
+	createRevisionQuery := `
+		INSERT INTO post_revisions (
+			post_id,
+			revision,
+			title,
+			content
+		)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3
+		FROM post_revisions
+		WHERE post_id = $1
+	`
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, updatePostQuery,
+			p.Title,
+			p.Slug,
+			p.Content,
+			p.ContentHTML,
+			p.SearchLanguage,
+			p.ID,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, createRevisionQuery,
+			p.ID,
+			p.Title,
+			p.Content,
+		)
+		if err != nil || tags == nil {
+			return err
+		}
+
+		return setPostTags(ctx, tx, p.ID, tags)
+	})
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func (r *Repository) UpdateStatus(ctx context.Context, p post.Post) error {
+	query := `
+		UPDATE posts SET
+			status = $1,
+			published_at = $2
+		WHERE
+			id = $3
+			AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.Status,
+		p.PublishedAt,
+		p.ID,
+	)
+	return err
+}
@@ -0,0 +1,103 @@
+package post
+
+import (
+	"html"
+	"strings"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
+)
+
+const maxSearchLength = 200
+
+// TSQuery turns a search into the text of a Postgres tsquery that matches
+// posts with all of its words. Words in double quotes have to appear next to
+// each other, and a word ending in `*` matches any word it starts, e.g.
+// `"open source" data*`. It is empty when the search has no words.
+func TSQuery(search string) string {
+	var groups []string
+	for i, part := range strings.Split(search, `"`) {
+		var terms []string
+		for _, word := range strings.Fields(part) {
+			if term := tsQueryTerm(word); term != "" {
+				terms = append(terms, term)
+			}
+		}
+		if len(terms) == 0 {
+			continue
+		}
+		// Odd parts are between quotes
+		if i%2 == 1 && len(terms) > 1 {
+			groups = append(groups, "("+strings.Join(terms, " <-> ")+")")
+			continue
+		}
+		groups = append(groups, terms...)
+	}
+	return strings.Join(groups, " & ")
+}
+
+// tsQueryTerm quotes word so characters like `&` or `!` are searched for
+// instead of read as operators.
+func tsQueryTerm(word string) string {
+	prefix := strings.HasSuffix(word, "*")
+	word = strings.TrimRight(word, "*")
+	if word == "" {
+		return ""
+	}
+
+	term := "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(word) + "'"
+	if prefix {
+		term += ":*"
+	}
+	return term
+}
+
+// ValidateSearch checks the search and page of `GET /api/v1/posts/search`.
+// Results are sorted by rank, which cursors do not support.
+func ValidateSearch(search string, p pagination.Request) error {
+	v := validation.Validator{}
+	v.MaxLength("q", search, maxSearchLength)
+	v.Check(TSQuery(search) != "", "q", validation.CodeRequired, "must contain a word to search for")
+	v.Check(p.Cursor.IsZero(), "cursor", validation.CodeInvalidFormat, "is not supported, search results are paginated with page")
+	return v.Err()
+}
+
+// Matches in a search headline are wrapped in these private use characters,
+// which are replaced with `<mark>` tags once the rest has been HTML escaped.
+const (
+	HeadlineStartSel = "\ue000"
+	HeadlineStopSel  = "\ue001"
+)
+
+// SearchResult is a post matching a search.
+type SearchResult struct {
+	PostWithAuthor
+	Rank float32
+	// Headline is an excerpt of the content around the matches.
+	Headline string
+}
+
+type PostSearchItem struct {
+	PostItem
+	// Rank orders the results, it is only comparable within one search.
+	Rank float32 `json:"rank" example:"0.6079271"`
+	// Snippet is an excerpt of the content, HTML escaped, with the matching
+	// words in `<mark>` tags.
+	Snippet string `json:"snippet" example:"... the content of my <mark>first</mark> blog post..."`
+}
+
+type PostSearchResponse struct {
+	// Posts are sorted from the best match.
+	Posts      []PostSearchItem `json:"posts"`
+	TotalCount int              `json:"total_count" example:"100"`
+	Page       int              `json:"page" example:"1"`
+	PageSize   int              `json:"page_size" example:"10"`
+}
+
+// Snippet returns the headline as HTML.
+func (r *SearchResult) Snippet() string {
+	return strings.NewReplacer(
+		HeadlineStartSel, "<mark>",
+		HeadlineStopSel, "</mark>",
+	).Replace(html.EscapeString(r.Headline))
+}
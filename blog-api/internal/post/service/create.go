@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/markdown"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
@@ -38,18 +39,33 @@ func (s *Service) Create(ctx context.Context, authorID uuid.UUID, req post.Creat
 		}
 	}
 
+	contentHTML, err := markdown.Render(req.Content)
+	if err != nil {
+		return post.PostID{}, err
+	}
+
 	now := time.Now()
 	p := &post.Post{
-		ID:        uuid.Must(uuid.NewV7()),
-		Title:     req.Title,
-		Slug:      slug,
-		Content:   req.Content,
-		AuthorID:  authorID,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:             uuid.Must(uuid.NewV7()),
+		Title:          req.Title,
+		Slug:           slug,
+		Content:        req.Content,
+		ContentHTML:    contentHTML,
+		AuthorID:       authorID,
+		Status:         req.Status,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		SearchLanguage: s.config.SearchLanguage,
+	}
+	switch p.Status {
+	case "", post.StatusPublished:
+		p.Status = post.StatusPublished
+		p.PublishedAt = &now
+	case post.StatusScheduled:
+		p.PublishedAt = req.PublishAt
 	}
 
-	if err := s.repo.Create(ctx, p); err != nil {
+	if err := s.repo.Create(ctx, p, post.NewTags(req.Tags)); err != nil {
 		return post.PostID{}, err
 	}
 
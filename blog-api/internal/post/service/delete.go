@@ -5,10 +5,11 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (s *Service) Delete(ctx context.Context, postID, authorID uuid.UUID) (post.PostID, error) {
+func (s *Service) Delete(ctx context.Context, postID uuid.UUID, actor policy.Actor) (post.PostID, error) {
 	p, err := s.repo.FindByID(ctx, postID)
 	if err != nil {
 		return post.PostID{}, err
@@ -17,8 +18,7 @@ func (s *Service) Delete(ctx context.Context, postID, authorID uuid.UUID) (post.
 		return post.PostID{}, post.ErrPostNotFound
 	}
 
-	// Check if the user is the author
-	if p.AuthorID != authorID {
+	if !policy.CanDelete(actor, p.AuthorID) {
 		return post.PostID{}, post.ErrUnauthorized
 	}
 
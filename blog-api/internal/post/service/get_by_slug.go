@@ -3,16 +3,41 @@ package service
 import (
 	"context"
 
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/markdown"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (s *Service) GetBySlug(ctx context.Context, slug string) (post.PostItem, error) {
-	p, err := s.repo.FindBySlugWithAuthor(ctx, slug)
+// GetBySlug returns a published post, or any post of the viewer. As HTML, the
+// content is replaced with its rendered HTML and table of contents.
+func (s *Service) GetBySlug(ctx context.Context, slug string, viewerID uuid.UUID, format post.Format) (post.PostItem, error) {
+	p, err := s.repo.FindBySlugWithAuthor(ctx, slug, viewerID)
 	if err != nil {
 		return post.PostItem{}, err
 	}
 	if p == (post.PostWithAuthor{}) {
 		return post.PostItem{}, post.ErrPostNotFound
 	}
-	return p.ToPostItem(), nil
+
+	items, err := s.withTags(ctx, []post.PostWithAuthor{p})
+	if err != nil {
+		return post.PostItem{}, err
+	}
+	item := items[0]
+	if format != post.FormatHTML {
+		return item, nil
+	}
+
+	// Posts not saved since the HTML is stored are rendered on the fly
+	item.ContentHTML = p.ContentHTML
+	if item.ContentHTML == "" && p.Content != "" {
+		item.ContentHTML, err = markdown.Render(p.Content)
+		if err != nil {
+			return post.PostItem{}, err
+		}
+	}
+	item.TOC = markdown.TOC(p.Content)
+	item.Content = ""
+	return item, nil
 }
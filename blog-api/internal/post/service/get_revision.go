@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/diff"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// GetRevision returns a revision of a post with the changes from it to the
+// current content.
+func (s *Service) GetRevision(ctx context.Context, postID uuid.UUID, revision int, actor policy.Actor) (post.RevisionResponse, error) {
+	p, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return post.RevisionResponse{}, err
+	}
+	if p == (post.Post{}) {
+		return post.RevisionResponse{}, post.ErrPostNotFound
+	}
+
+	if !policy.CanViewRevisions(actor, p.AuthorID) {
+		return post.RevisionResponse{}, post.ErrUnauthorized
+	}
+
+	rev, err := s.repo.FindRevision(ctx, postID, revision)
+	if err != nil {
+		return post.RevisionResponse{}, err
+	}
+	if rev == (post.Revision{}) {
+		return post.RevisionResponse{}, post.ErrRevisionNotFound
+	}
+
+	return post.RevisionResponse{
+		Revision:  rev.Revision,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		CreatedAt: rev.CreatedAt,
+		Diff:      diff.Lines(rev.Content, p.Content),
+	}, nil
+}
@@ -3,31 +3,79 @@ package service
 import (
 	"context"
 
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (s *Service) List(ctx context.Context, page, pageSize int) (post.PostListResponse, error) {
-	if page < 1 {
-		page = 1
+// List lists the published posts, and the unpublished posts of the
+// filter's viewer.
+func (s *Service) List(ctx context.Context, filter post.ListFilter, p pagination.Request) (post.PostListResponse, error) {
+	return s.list(ctx, filter, p)
+}
+
+func (s *Service) list(ctx context.Context, filter post.ListFilter, p pagination.Request) (post.PostListResponse, error) {
+	p.Normalize()
+	if err := p.CheckSort(filter.Sort.String()); err != nil {
+		return post.PostListResponse{}, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	posts, err := s.repo.FindAll(ctx, filter, p)
+	if err != nil {
+		return post.PostListResponse{}, err
 	}
+	page := pagination.Paginate(p, posts, func(item post.PostWithAuthor) pagination.Cursor {
+		return pagination.Cursor{Sort: filter.Sort.String(), Key: filter.Sort.CursorKey(item), ID: item.ID}
+	})
 
-	posts, totalCount, err := s.repo.FindAll(ctx, page, pageSize)
+	postItems, err := s.withTags(ctx, page.Rows)
 	if err != nil {
 		return post.PostListResponse{}, err
 	}
 
+	response := post.PostListResponse{
+		Posts:      postItems,
+		PageSize:   p.PageSize,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	if p.Cursor.IsZero() {
+		response.Page = p.Page
+	}
+	if p.IncludeTotal {
+		totalCount, err := s.repo.CountAll(ctx, filter)
+		if err != nil {
+			return post.PostListResponse{}, err
+		}
+		response.TotalCount = &totalCount
+	}
+	return response, nil
+}
+
+// withTags converts posts to post items with their tags, loaded for all of
+// them at once.
+func (s *Service) withTags(ctx context.Context, posts []post.PostWithAuthor) ([]post.PostItem, error) {
+	if len(posts) == 0 {
+		return []post.PostItem{}, nil
+	}
+
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.ID
+	}
+
+	tags, err := s.repo.FindTagsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	postItems := make([]post.PostItem, len(posts))
 	for i, p := range posts {
 		postItems[i] = p.ToPostItem()
+		if postTags, ok := tags[p.ID]; ok {
+			postItems[i].Tags = postTags
+		}
 	}
-
-	return post.PostListResponse{
-		Posts:      postItems,
-		TotalCount: totalCount,
-		Page:       page,
-		PageSize:   pageSize,
-	}, nil
+	return postItems, nil
 }
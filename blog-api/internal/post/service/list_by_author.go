@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// ListByAuthor lists the posts of the user with the given username, only
+// the published ones unless the viewer is that user.
+func (s *Service) ListByAuthor(ctx context.Context, username string, viewerID uuid.UUID, p pagination.Request) (post.PostListResponse, error) {
+	authorID, err := s.repo.FindAuthorIDByUsername(ctx, username)
+	if err != nil {
+		return post.PostListResponse{}, err
+	}
+	if authorID == uuid.Nil {
+		return post.PostListResponse{}, post.ErrAuthorNotFound
+	}
+
+	return s.list(ctx, post.ListFilter{AuthorID: authorID, ViewerID: viewerID}, p)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func (s *Service) ListRevisions(ctx context.Context, postID uuid.UUID, actor policy.Actor) (post.RevisionListResponse, error) {
+	p, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return post.RevisionListResponse{}, err
+	}
+	if p == (post.Post{}) {
+		return post.RevisionListResponse{}, post.ErrPostNotFound
+	}
+
+	if !policy.CanViewRevisions(actor, p.AuthorID) {
+		return post.RevisionListResponse{}, post.ErrUnauthorized
+	}
+
+	revisions, err := s.repo.FindRevisions(ctx, postID)
+	if err != nil {
+		return post.RevisionListResponse{}, err
+	}
+
+	items := make([]post.RevisionItem, len(revisions))
+	for i, rev := range revisions {
+		items[i] = rev.ToRevisionItem()
+	}
+
+	return post.RevisionListResponse{Revisions: items}, nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+func (s *Service) ListTags(ctx context.Context) (post.TagListResponse, error) {
+	tags, err := s.repo.FindTags(ctx)
+	if err != nil {
+		return post.TagListResponse{}, err
+	}
+	if tags == nil {
+		tags = []post.TagItem{}
+	}
+	return post.TagListResponse{Tags: tags}, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// Publish makes a post visible to everyone now, or schedules it for
+// req.PublishAt.
+func (s *Service) Publish(ctx context.Context, postID uuid.UUID, actor policy.Actor, req post.PublishPostRequest) (post.PostStatusResponse, error) {
+	if err := req.Validate(); err != nil {
+		return post.PostStatusResponse{}, err
+	}
+
+	p, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return post.PostStatusResponse{}, err
+	}
+	if p == (post.Post{}) {
+		return post.PostStatusResponse{}, post.ErrPostNotFound
+	}
+
+	if !policy.CanUpdate(actor, p.AuthorID) {
+		return post.PostStatusResponse{}, post.ErrUnauthorized
+	}
+
+	if p.Status == post.StatusPublished {
+		return post.PostStatusResponse{}, post.ErrAlreadyPublished
+	}
+
+	if req.PublishAt != nil {
+		p.Status = post.StatusScheduled
+		p.PublishedAt = req.PublishAt
+	} else {
+		now := time.Now()
+		p.Status = post.StatusPublished
+		p.PublishedAt = &now
+	}
+
+	if err := s.repo.UpdateStatus(ctx, p); err != nil {
+		return post.PostStatusResponse{}, err
+	}
+
+	return post.PostStatusResponse{
+		ID:          p.ID.String(),
+		Status:      p.Status,
+		PublishedAt: p.PublishedAt,
+	}, nil
+}
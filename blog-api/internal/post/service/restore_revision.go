@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// RestoreRevision makes the title and content of a revision current again,
+// the tags are kept. It is saved as a new revision, so the restore itself
+// can be undone.
+func (s *Service) RestoreRevision(ctx context.Context, postID uuid.UUID, revision int, actor policy.Actor) (post.PostID, error) {
+	p, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return post.PostID{}, err
+	}
+	if p == (post.Post{}) {
+		return post.PostID{}, post.ErrPostNotFound
+	}
+
+	if !policy.CanUpdate(actor, p.AuthorID) {
+		return post.PostID{}, post.ErrUnauthorized
+	}
+
+	rev, err := s.repo.FindRevision(ctx, postID, revision)
+	if err != nil {
+		return post.PostID{}, err
+	}
+	if rev == (post.Revision{}) {
+		return post.PostID{}, post.ErrRevisionNotFound
+	}
+
+	return s.update(ctx, p, rev.Title, rev.Content, nil)
+}
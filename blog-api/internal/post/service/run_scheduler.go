@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunScheduler publishes scheduled posts once they are due, until ctx is
+// canceled. A post is published up to one SchedulerInterval late.
+func (s *Service) RunScheduler(ctx context.Context) {
+	if s.config.SchedulerInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.SchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.PublishScheduled(ctx)
+		}
+	}
+}
+
+// PublishScheduled publishes the scheduled posts that are due.
+func (s *Service) PublishScheduled(ctx context.Context) {
+	published, err := s.repo.PublishScheduled(ctx)
+	if err != nil {
+		slog.Error("Failed to publish scheduled posts",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	if published > 0 {
+		slog.Info("Published scheduled posts",
+			slog.Int64("published", published),
+		)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/pagination"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// CheckSearchLanguage makes sure the configured search language exists,
+// otherwise saving posts would fail.
+func (s *Service) CheckSearchLanguage(ctx context.Context) error {
+	return s.repo.CheckSearchLanguage(ctx, s.config.SearchLanguage)
+}
+
+// Search finds the published posts, and the unpublished posts of the
+// viewer, matching a search, from the best match.
+func (s *Service) Search(ctx context.Context, search string, viewerID uuid.UUID, p pagination.Request) (post.PostSearchResponse, error) {
+	if err := post.ValidateSearch(search, p); err != nil {
+		return post.PostSearchResponse{}, err
+	}
+	p.Normalize()
+
+	results, totalCount, err := s.repo.Search(ctx, s.config.SearchLanguage, post.TSQuery(search), viewerID, p.Page, p.PageSize)
+	if err != nil {
+		return post.PostSearchResponse{}, err
+	}
+
+	posts := make([]post.PostWithAuthor, len(results))
+	for i, res := range results {
+		posts[i] = res.PostWithAuthor
+	}
+	postItems, err := s.withTags(ctx, posts)
+	if err != nil {
+		return post.PostSearchResponse{}, err
+	}
+
+	items := make([]post.PostSearchItem, len(results))
+	for i, res := range results {
+		items[i] = post.PostSearchItem{
+			PostItem: postItems[i],
+			Rank:     res.Rank,
+			Snippet:  res.Snippet(),
+		}
+	}
+
+	return post.PostSearchResponse{
+		Posts:      items,
+		TotalCount: totalCount,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+	}, nil
+}
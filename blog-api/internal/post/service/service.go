@@ -5,16 +5,34 @@ import (
 	"encoding/hex"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/post/repository"
 )
 
+type Config struct {
+	// SchedulerInterval is how often scheduled posts are checked for being
+	// due, zero disables the scheduler.
+	SchedulerInterval time.Duration
+	// SearchLanguage is the Postgres text search configuration posts are
+	// indexed and searched with, e.g. `english` or `indonesian`. It defaults
+	// to `simple`, which does not stem words.
+	SearchLanguage string
+}
+
 type Service struct {
-	repo *repository.Repository
+	config Config
+	repo   *repository.Repository
 }
 
-func New(repo *repository.Repository) *Service {
-	return &Service{repo: repo}
+func New(config Config, repo *repository.Repository) *Service {
+	if config.SearchLanguage == "" {
+		config.SearchLanguage = "simple"
+	}
+	return &Service{
+		config: config,
+		repo:   repo,
+	}
 }
 
 func generateSlug(title string) (string, error) {
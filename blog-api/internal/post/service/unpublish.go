@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/post"
+)
+
+// Unpublish hides a post from everyone but its author. A published post is
+// archived and keeps the time it was published, a scheduled post goes back
+// to being a draft.
+func (s *Service) Unpublish(ctx context.Context, postID uuid.UUID, actor policy.Actor) (post.PostStatusResponse, error) {
+	p, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return post.PostStatusResponse{}, err
+	}
+	if p == (post.Post{}) {
+		return post.PostStatusResponse{}, post.ErrPostNotFound
+	}
+
+	if !policy.CanUpdate(actor, p.AuthorID) {
+		return post.PostStatusResponse{}, post.ErrUnauthorized
+	}
+
+	switch p.Status {
+	case post.StatusPublished:
+		p.Status = post.StatusArchived
+	case post.StatusScheduled:
+		p.Status = post.StatusDraft
+		p.PublishedAt = nil
+	default:
+		return post.PostStatusResponse{}, post.ErrNotPublished
+	}
+
+	if err := s.repo.UpdateStatus(ctx, p); err != nil {
+		return post.PostStatusResponse{}, err
+	}
+
+	return post.PostStatusResponse{
+		ID:          p.ID.String(),
+		Status:      p.Status,
+		PublishedAt: p.PublishedAt,
+	}, nil
+}
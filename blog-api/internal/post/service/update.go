@@ -5,10 +5,12 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/markdown"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/post"
 )
 
-func (s *Service) Update(ctx context.Context, postID, authorID uuid.UUID, req post.UpdatePostRequest) (post.PostID, error) {
+func (s *Service) Update(ctx context.Context, postID uuid.UUID, actor policy.Actor, req post.UpdatePostRequest) (post.PostID, error) {
 	if err := req.Validate(); err != nil {
 		return post.PostID{}, err
 	}
@@ -21,22 +23,28 @@ func (s *Service) Update(ctx context.Context, postID, authorID uuid.UUID, req po
 		return post.PostID{}, post.ErrPostNotFound
 	}
 
-	// Check if the user is the author
-	if p.AuthorID != authorID {
+	if !policy.CanUpdate(actor, p.AuthorID) {
 		return post.PostID{}, post.ErrUnauthorized
 	}
 
+	return s.update(ctx, p, req.Title, req.Content, post.NewTags(req.Tags))
+}
+
+// update saves a new title and content of p as its next revision, and
+// replaces its tags unless tags is nil.
+func (s *Service) update(ctx context.Context, p post.Post, title, content string, tags []post.Tag) (post.PostID, error) {
 	// Generate new slug if title changed
 	newSlug := p.Slug
-	if p.Title != req.Title {
-		newSlug, err = generateSlug(req.Title)
+	if p.Title != title {
+		var err error
+		newSlug, err = generateSlug(title)
 		if err != nil {
 			return post.PostID{}, err
 		}
 		// Ensure new slug is unique (excluding current post)
 		const maxSlugRetries = 5
 		for i := range maxSlugRetries {
-			exists, err := s.repo.ExistsBySlugExcludingID(ctx, newSlug, postID)
+			exists, err := s.repo.ExistsBySlugExcludingID(ctx, newSlug, p.ID)
 			if err != nil {
 				return post.PostID{}, err
 			}
@@ -46,18 +54,25 @@ func (s *Service) Update(ctx context.Context, postID, authorID uuid.UUID, req po
 			if i == maxSlugRetries-1 {
 				return post.PostID{}, post.ErrSlugGenerationFail
 			}
-			newSlug, err = generateSlug(req.Title)
+			newSlug, err = generateSlug(title)
 			if err != nil {
 				return post.PostID{}, err
 			}
 		}
 	}
 
-	p.Title = req.Title
+	contentHTML, err := markdown.Render(content)
+	if err != nil {
+		return post.PostID{}, err
+	}
+
+	p.Title = title
 	p.Slug = newSlug
-	p.Content = req.Content
+	p.Content = content
+	p.ContentHTML = contentHTML
+	p.SearchLanguage = s.config.SearchLanguage
 
-	if err := s.repo.Update(ctx, p); err != nil {
+	if err := s.repo.Update(ctx, p, tags); err != nil {
 		return post.PostID{}, err
 	}
 
@@ -0,0 +1,57 @@
+package post
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+)
+
+type Tag struct {
+	// Slug identifies the tag in URLs, e.g. `?tag=machine-learning`.
+	Slug string `json:"slug" example:"machine-learning"`
+	// Name is the tag as it was first written.
+	Name string `json:"name" example:"Machine Learning"`
+}
+
+var tagSlugSeparators = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// TagSlug returns the form tags are compared and looked up in: NFKC
+// normalized, lowercased, with every run of characters other than letters
+// and digits replaced by a hyphen. It is empty when name has neither.
+func TagSlug(name string) string {
+	return strings.Trim(tagSlugSeparators.ReplaceAllString(normalize.Identifier(name), "-"), "-")
+}
+
+// NewTags turns the tag names of a request into tags, dropping names with
+// the same slug as an earlier one.
+func NewTags(names []string) []Tag {
+	if names == nil {
+		return nil
+	}
+
+	tags := make([]Tag, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		slug := TagSlug(name)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, Tag{Slug: slug, Name: strings.TrimSpace(name)})
+	}
+	return tags
+}
+
+type TagItem struct {
+	Slug string `json:"slug" example:"machine-learning"`
+	Name string `json:"name" example:"Machine Learning"`
+	// PostCount only counts published posts.
+	PostCount int `json:"post_count" example:"12"`
+}
+
+type TagListResponse struct {
+	// Tags are sorted from the most used, tags without published posts are
+	// left out.
+	Tags []TagItem `json:"tags"`
+}
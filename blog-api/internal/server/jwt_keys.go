@@ -0,0 +1,238 @@
+package server
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// KeySetConfig describes where the signing and verification keys come from.
+// HS256 uses SecretKey, RS256 and EdDSA read PEM files.
+type KeySetConfig struct {
+	Algorithm      string
+	SecretKey      string
+	SigningKeyID   string
+	SigningKeyFile string
+	// VerificationKeyFiles maps a key ID to a PEM file holding a public (or
+	// private) key that is still accepted for verification, e.g. the previous
+	// signing key during a rotation.
+	VerificationKeyFiles map[string]string
+}
+
+type verificationKey struct {
+	id     string
+	method jwt.SigningMethod
+	key    any
+}
+
+// KeySet holds the key used to sign new tokens and every key that is
+// accepted when verifying them, indexed by the `kid` header.
+type KeySet struct {
+	signingID     string
+	signingMethod jwt.SigningMethod
+	signingKey    any
+	verification  map[string]verificationKey
+}
+
+// NewHMACKeySet returns a key set that signs and verifies with a shared secret.
+func NewHMACKeySet(secretKey string) *KeySet {
+	key := []byte(secretKey)
+	return &KeySet{
+		signingMethod: jwt.SigningMethodHS256,
+		signingKey:    key,
+		verification: map[string]verificationKey{
+			"": {method: jwt.SigningMethodHS256, key: key},
+		},
+	}
+}
+
+func LoadKeySet(config KeySetConfig) (*KeySet, error) {
+	if config.Algorithm == "" || config.Algorithm == AlgorithmHS256 {
+		ks := NewHMACKeySet(config.SecretKey)
+		if config.SigningKeyID != "" {
+			ks.signingID = config.SigningKeyID
+			ks.verification[config.SigningKeyID] = verificationKey{
+				id:     config.SigningKeyID,
+				method: jwt.SigningMethodHS256,
+				key:    ks.signingKey,
+			}
+		}
+		return ks, nil
+	}
+
+	if config.SigningKeyID == "" {
+		return nil, fmt.Errorf("signing key ID is required for %s", config.Algorithm)
+	}
+
+	pemBytes, err := os.ReadFile(config.SigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	method, private, public, err := parsePrivateKey(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	if method.Alg() != config.Algorithm {
+		return nil, fmt.Errorf("signing key is not a %s key", config.Algorithm)
+	}
+
+	ks := &KeySet{
+		signingID:     config.SigningKeyID,
+		signingMethod: method,
+		signingKey:    private,
+		verification: map[string]verificationKey{
+			config.SigningKeyID: {id: config.SigningKeyID, method: method, key: public},
+		},
+	}
+
+	for kid, path := range config.VerificationKeyFiles {
+		if _, exists := ks.verification[kid]; exists {
+			return nil, fmt.Errorf("duplicate key ID %q", kid)
+		}
+
+		pemBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read verification key %q: %w", kid, err)
+		}
+
+		method, public, err := parsePublicKey(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse verification key %q: %w", kid, err)
+		}
+
+		ks.verification[kid] = verificationKey{id: kid, method: method, key: public}
+	}
+
+	return ks, nil
+}
+
+// Methods returns the algorithms the key set can verify.
+func (ks *KeySet) Methods() []string {
+	seen := map[string]bool{}
+	methods := []string{}
+	for _, vk := range ks.verification {
+		if !seen[vk.method.Alg()] {
+			seen[vk.method.Alg()] = true
+			methods = append(methods, vk.method.Alg())
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	if ks.signingID != "" {
+		token.Header["kid"] = ks.signingID
+	}
+	return token.SignedString(ks.signingKey)
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	vk, ok := ks.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID: %q", kid)
+	}
+
+	// Never let the token pick the algorithm for a key, otherwise a public key
+	// could be used as an HMAC secret.
+	if token.Method.Alg() != vk.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return vk.key, nil
+}
+
+// JSONWebKey is a public key in the RFC 7517 format.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public verification keys. Shared HMAC secrets are never
+// published, so an HS256 key set returns an empty list.
+func (ks *KeySet) JWKS() JSONWebKeySet {
+	keys := []JSONWebKey{}
+	for _, vk := range ks.verification {
+		switch pub := vk.key.(type) {
+		case *rsa.PublicKey:
+			keys = append(keys, JSONWebKey{
+				KeyType:   "RSA",
+				KeyID:     vk.id,
+				Use:       "sig",
+				Algorithm: vk.method.Alg(),
+				N:         base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			keys = append(keys, JSONWebKey{
+				KeyType:   "OKP",
+				KeyID:     vk.id,
+				Use:       "sig",
+				Algorithm: vk.method.Alg(),
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyID < keys[j].KeyID })
+	return JSONWebKeySet{Keys: keys}
+}
+
+func parsePrivateKey(pemBytes []byte) (jwt.SigningMethod, crypto.PrivateKey, crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes); err == nil {
+		return jwt.SigningMethodRS256, key, &key.PublicKey, nil
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes); err == nil {
+		signer, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("unsupported private key type")
+		}
+		return jwt.SigningMethodEdDSA, signer, signer.Public(), nil
+	}
+	return nil, nil, nil, fmt.Errorf("unsupported private key type")
+}
+
+func parsePublicKey(pemBytes []byte) (jwt.SigningMethod, crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+		return jwt.SigningMethodRS256, key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(pemBytes); err == nil {
+		return jwt.SigningMethodEdDSA, key, nil
+	}
+
+	// Accept a private key too, so the previous signing key file can be
+	// reused as-is during a rotation.
+	method, _, public, err := parsePrivateKey(pemBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unsupported public key type")
+	}
+	return method, public, nil
+}
@@ -2,11 +2,15 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 )
 
 type contextKey string
@@ -16,23 +20,119 @@ const (
 	UserTokenKey  contextKey = "user_token"
 )
 
+var (
+	ErrEmailNotVerified  = appError.New(http.StatusForbidden, "EMAIL_NOT_VERIFIED", "Email must be verified to perform this action")
+	ErrInsufficientRole  = appError.New(http.StatusForbidden, "INSUFFICIENT_ROLE", "Your role does not allow this action")
+	ErrInsufficientScope = appError.New(http.StatusForbidden, "INSUFFICIENT_SCOPE", "The access token's scopes do not allow this action")
+)
+
+// PersonalAccessTokenPrefix marks bearer tokens that are personal access
+// tokens rather than JWTs.
+const PersonalAccessTokenPrefix = "pat_"
+
+// TokenValidator runs stateful checks, such as revocation, on a token whose
+// signature and expiry have already been verified.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, claims UserClaims) error
+}
+
+// PersonalAccessTokenAuthenticator resolves a personal access token to the
+// claims of its owner.
+type PersonalAccessTokenAuthenticator interface {
+	AuthenticatePersonalAccessToken(ctx context.Context, token string) (UserClaims, error)
+}
+
+// EmailVerificationChecker looks up whether a user has verified their email,
+// which may have happened after their token was issued.
+type EmailVerificationChecker interface {
+	IsEmailVerified(ctx context.Context, userID string) (bool, error)
+}
+
 type JWTConfig struct {
-	SecretKey string
+	Keys     *KeySet
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew between the issuer and this server when
+	// checking exp, nbf and iat.
+	Leeway         time.Duration
+	TokenValidator TokenValidator
+	// PersonalAccessTokens accepts `pat_` bearer tokens when set. They are
+	// only allowed on routes that require a scope the token carries.
+	PersonalAccessTokens PersonalAccessTokenAuthenticator
+	// RequireVerifiedEmail turns on the RequireVerifiedEmail route option,
+	// when false the option is ignored.
+	RequireVerifiedEmail bool
+	// EmailVerification is asked about tokens issued before their user
+	// verified the email, when nil those need a new token to pass.
+	EmailVerification EmailVerificationChecker
 }
 
 type JWTMiddleware struct {
-	secretKey []byte
+	keys                 *KeySet
+	parser               *jwt.Parser
+	tokenValidator       TokenValidator
+	personalAccessTokens PersonalAccessTokenAuthenticator
+	requireVerifiedEmail bool
+	emailVerification    EmailVerificationChecker
 }
 
 type UserClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	TokenID       string      `json:"jti"`
+	UserID        string      `json:"user_id"`
+	Username      string      `json:"username"`
+	Email         string      `json:"email"`
+	EmailVerified bool        `json:"email_verified"`
+	Role          policy.Role `json:"role"`
+	TokenVersion  int         `json:"ver"`
+	// SessionID is the login the token was issued for, the TokenValidator
+	// rejects it once the session is signed out.
+	SessionID string    `json:"sid"`
+	ExpiresAt time.Time `json:"exp"`
+	// PersonalAccessToken is set when the request was authenticated with a
+	// personal access token, which is limited to Scopes.
+	PersonalAccessToken bool           `json:"-"`
+	Scopes              []policy.Scope `json:"-"`
+}
+
+// RouteOption adds a requirement a protected route checks on top of a valid
+// token.
+type RouteOption func(*routeOptions)
+
+type routeOptions struct {
+	verifiedEmail bool
+	role          policy.Role
+	scope         policy.Scope
+}
+
+// RequireVerifiedEmail rejects tokens of users who have not verified their
+// email yet, when enabled in JWTConfig.
+func RequireVerifiedEmail() RouteOption {
+	return func(o *routeOptions) {
+		o.verifiedEmail = true
+	}
 }
 
 func NewJWTMiddleware(config JWTConfig) *JWTMiddleware {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(config.Keys.Methods()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		opts = append(opts, jwt.WithAudience(config.Audience))
+	}
+
 	return &JWTMiddleware{
-		secretKey: []byte(config.SecretKey),
+		keys:                 config.Keys,
+		parser:               jwt.NewParser(opts...),
+		tokenValidator:       config.TokenValidator,
+		personalAccessTokens: config.PersonalAccessTokens,
+		requireVerifiedEmail: config.RequireVerifiedEmail,
+		emailVerification:    config.EmailVerification,
 	}
 }
 
@@ -40,48 +140,165 @@ func (m *JWTMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			ErrorResponse(w, http.StatusUnauthorized, "Authorization header is required", nil)
+			ErrorResponse(w, r, http.StatusUnauthorized, "Authorization header is required", nil)
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			ErrorResponse(w, http.StatusUnauthorized, "Invalid authorization header format", nil)
+			ErrorResponse(w, r, http.StatusUnauthorized, "Invalid authorization header format", nil)
 			return
 		}
 
 		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		if m.personalAccessTokens != nil && strings.HasPrefix(tokenString, PersonalAccessTokenPrefix) {
+			userClaims, err := m.personalAccessTokens.AuthenticatePersonalAccessToken(r.Context(), tokenString)
+			if err != nil {
+				authenticationError(w, r, err)
+				return
 			}
-			return m.secretKey, nil
-		})
 
+			next.ServeHTTP(w, r.WithContext(withUserClaims(r.Context(), userClaims, tokenString)))
+			return
+		}
+
+		token, err := m.parser.Parse(tokenString, m.keys.keyFunc)
 		if err != nil || !token.Valid {
-			ErrorResponse(w, http.StatusUnauthorized, "Invalid or expired token", nil)
+			ErrorResponse(w, r, http.StatusUnauthorized, "Invalid or expired token", nil)
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			ErrorResponse(w, http.StatusUnauthorized, "Invalid token claims", nil)
+			ErrorResponse(w, r, http.StatusUnauthorized, "Invalid token claims", nil)
+			return
+		}
+
+		// The parser validates nbf when it is present, tokens issued by this
+		// service always carry it.
+		if nbf, err := claims.GetNotBefore(); err != nil || nbf == nil {
+			ErrorResponse(w, r, http.StatusUnauthorized, "Invalid token claims", nil)
 			return
 		}
 
 		userClaims := extractUserClaims(claims)
+		if userClaims.TokenID == "" {
+			ErrorResponse(w, r, http.StatusUnauthorized, "Invalid token claims", nil)
+			return
+		}
+
+		if m.tokenValidator != nil {
+			if err := m.tokenValidator.ValidateToken(r.Context(), userClaims); err != nil {
+				authenticationError(w, r, err)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(withUserClaims(r.Context(), userClaims, tokenString)))
+	})
+}
+
+// OptionalMiddleware authenticates requests that carry an Authorization
+// header like Middleware, and lets anonymous requests through without
+// claims.
+func (m *JWTMiddleware) OptionalMiddleware(next http.Handler) http.Handler {
+	authenticated := m.Middleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		authenticated.ServeHTTP(w, r)
+	})
+}
+
+// authenticationError rejects the request with the reason when err is an
+// AppError, anything else is an internal failure.
+func authenticationError(w http.ResponseWriter, r *http.Request, err error) {
+	if appError.GetCode(err) == "" {
+		ErrorResponse(w, r, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+	ErrorResponse(w, r, http.StatusUnauthorized, "", err)
+}
+
+func withUserClaims(ctx context.Context, claims UserClaims, token string) context.Context {
+	ctx = context.WithValue(ctx, UserClaimsKey, claims)
+	return context.WithValue(ctx, UserTokenKey, token)
+}
+
+// RequireRole rejects tokens whose role does not include the given one.
+func RequireRole(role policy.Role) RouteOption {
+	return func(o *routeOptions) {
+		o.role = role
+	}
+}
+
+// RequireScope lets personal access tokens carrying the given scope use the
+// route. Routes without it reject personal access tokens.
+func RequireScope(scope policy.Scope) RouteOption {
+	return func(o *routeOptions) {
+		o.scope = scope
+	}
+}
+
+// Authorize checks the route options against the claims the Middleware put
+// in the request context, so it must run after it.
+func (m *JWTMiddleware) Authorize(next http.Handler, opts ...RouteOption) http.Handler {
+	options := routeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := GetUserClaims(r.Context())
+		if !ok {
+			ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+			return
+		}
+
+		if claims.PersonalAccessToken && (options.scope == "" || !slices.Contains(claims.Scopes, options.scope)) {
+			ErrorResponse(w, r, http.StatusForbidden, "", ErrInsufficientScope)
+			return
+		}
+
+		if options.verifiedEmail && m.requireVerifiedEmail && !claims.EmailVerified {
+			verified, err := m.isEmailVerified(r.Context(), claims)
+			if err != nil {
+				HandleError(w, r, err)
+				return
+			}
+			if !verified {
+				ErrorResponse(w, r, http.StatusForbidden, "", ErrEmailNotVerified)
+				return
+			}
+		}
 
-		ctx := context.WithValue(r.Context(), UserClaimsKey, userClaims)
-		ctx = context.WithValue(ctx, UserTokenKey, tokenString)
+		if options.role != "" && !claims.Role.AtLeast(options.role) {
+			ErrorResponse(w, r, http.StatusForbidden, "", ErrInsufficientRole)
+			return
+		}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r)
 	})
 }
 
+// isEmailVerified tells whether the user of a token carrying an unverified
+// email has verified it since, so they do not have to get a new token first.
+func (m *JWTMiddleware) isEmailVerified(ctx context.Context, claims UserClaims) (bool, error) {
+	if m.emailVerification == nil {
+		return false, nil
+	}
+	return m.emailVerification.IsEmailVerified(ctx, claims.UserID)
+}
+
 func extractUserClaims(claims jwt.MapClaims) UserClaims {
 	userClaims := UserClaims{}
 
+	if tokenID, ok := claims["jti"].(string); ok {
+		userClaims.TokenID = tokenID
+	}
 	if userID, ok := claims["user_id"].(string); ok {
 		userClaims.UserID = userID
 	}
@@ -91,6 +308,21 @@ func extractUserClaims(claims jwt.MapClaims) UserClaims {
 	if email, ok := claims["email"].(string); ok {
 		userClaims.Email = email
 	}
+	if emailVerified, ok := claims["email_verified"].(bool); ok {
+		userClaims.EmailVerified = emailVerified
+	}
+	if role, ok := claims["role"].(string); ok {
+		userClaims.Role = policy.Role(role)
+	}
+	if version, ok := claims["ver"].(float64); ok {
+		userClaims.TokenVersion = int(version)
+	}
+	if sessionID, ok := claims["sid"].(string); ok {
+		userClaims.SessionID = sessionID
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		userClaims.ExpiresAt = exp.Time
+	}
 
 	return userClaims
 }
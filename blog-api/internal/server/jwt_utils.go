@@ -4,29 +4,58 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+type JWTGeneratorConfig struct {
+	Keys          *KeySet
+	TokenDuration time.Duration
+	Issuer        string
+	Audience      string
+}
+
 type JWTGenerator struct {
-	secretKey     []byte
+	keys          *KeySet
 	tokenDuration time.Duration
+	issuer        string
+	audience      string
 }
 
-func NewJWTGenerator(secretKey string, tokenDuration time.Duration) *JWTGenerator {
+func NewJWTGenerator(config JWTGeneratorConfig) *JWTGenerator {
 	return &JWTGenerator{
-		secretKey:     []byte(secretKey),
-		tokenDuration: tokenDuration,
+		keys:          config.Keys,
+		tokenDuration: config.TokenDuration,
+		issuer:        config.Issuer,
+		audience:      config.Audience,
 	}
 }
 
-func (g *JWTGenerator) GenerateToken(userID, username, email string) (string, error) {
+// GenerateToken signs a new access token for the given claims. The token ID
+// and the time based claims are always set by the generator.
+func (g *JWTGenerator) GenerateToken(userClaims UserClaims) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
-		"user_id":  userID,
-		"username": username,
-		"email":    email,
-		"exp":      time.Now().Add(g.tokenDuration).Unix(),
-		"iat":      time.Now().Unix(),
+		"jti":            uuid.Must(uuid.NewV7()).String(),
+		"sub":            userClaims.UserID,
+		"user_id":        userClaims.UserID,
+		"username":       userClaims.Username,
+		"email":          userClaims.Email,
+		"email_verified": userClaims.EmailVerified,
+		"role":           userClaims.Role,
+		"ver":            userClaims.TokenVersion,
+		"exp":            now.Add(g.tokenDuration).Unix(),
+		"iat":            now.Unix(),
+		"nbf":            now.Unix(),
+	}
+	if userClaims.SessionID != "" {
+		claims["sid"] = userClaims.SessionID
+	}
+	if g.issuer != "" {
+		claims["iss"] = g.issuer
+	}
+	if g.audience != "" {
+		claims["aud"] = g.audience
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(g.secretKey)
+	return g.keys.sign(claims)
 }
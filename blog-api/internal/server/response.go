@@ -1,9 +1,16 @@
 package server
 
 import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
 )
 
 // APIResponse represents a standard API response
@@ -11,11 +18,49 @@ type APIResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error"`
 	Result  any    `json:"result"`
+	// Details lists the invalid fields of a request that failed validation.
+	Details []validation.Violation `json:"details,omitempty"`
 }
 
-func ErrorResponse(w http.ResponseWriter, statusCode int, message string, err error) {
+// ProblemContentType is answered instead of an APIResponse to clients that
+// accept it.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	// Type is `about:blank` for plain HTTP errors, otherwise a URN derived
+	// from Code, e.g. `urn:problem:post-not-found`.
+	Type     string `json:"type" example:"urn:problem:post-not-found"`
+	Title    string `json:"title" example:"Post not found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"Invalid post ID"`
+	Instance string `json:"instance,omitempty" example:"/api/v1/posts/my-first-blog-post-a1b2c3d4"`
+	// Code is the same error code as the error of an APIResponse.
+	Code    string                 `json:"code" example:"POST_NOT_FOUND"`
+	Details []validation.Violation `json:"details,omitempty"`
+}
+
+// HandleError answers err with the status its AppError carries. Anything
+// else is logged and answered as an internal server error without exposing
+// the cause.
+func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	status := appError.GetStatus(err)
+	if status >= http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "Request failed",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("error", err.Error()),
+		)
+		ErrorResponse(w, r, status, "Internal server error", nil)
+		return
+	}
+	ErrorResponse(w, r, status, "", err)
+}
+
+func ErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, message string, err error) {
 	errorCode := http.StatusText(statusCode)
 	errorMessage := message
+	var details []validation.Violation
 
 	if err != nil {
 		// Extract error code if it's an AppError
@@ -30,10 +75,55 @@ func ErrorResponse(w http.ResponseWriter, statusCode int, message string, err er
 			errorMessage = message
 		}
 
+		var invalid *validation.Error
+		if errors.As(err, &invalid) {
+			details = invalid.Violations
+		}
+	}
+
+	w.Header().Add("Vary", "Accept")
+	if !acceptsProblem(r) {
+		JSON(w, statusCode, APIResponse{
+			Message: errorMessage,
+			Error:   errorCode,
+			Details: details,
+		})
+		return
 	}
 
-	JSON(w, statusCode, APIResponse{
-		Message: errorMessage,
-		Error:   errorCode,
-	})
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   message,
+		Instance: r.URL.Path,
+		Code:     errorCode,
+		Details:  details,
+	}
+	if code := appError.GetCode(err); code != "" {
+		problem.Type = "urn:problem:" + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+		problem.Title = errorMessage
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to write problem", slog.String("error", err.Error()))
+	}
+}
+
+// acceptsProblem reports whether the Accept header lists the problem media
+// type, clients that do not ask for it keep getting an APIResponse.
+func acceptsProblem(r *http.Request) bool {
+	for accepted := range strings.SplitSeq(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(accepted)
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		return true
+	}
+	return false
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"runtime/debug"
 	"time"
@@ -85,11 +86,21 @@ func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *h
 	s.mux.Handle(pattern, LoggerMiddleware(RecoverMiddleware(CORSMiddleware((http.HandlerFunc(handler))))))
 }
 
-func (s *Server) HandleFuncWithAuth(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+func (s *Server) HandleFuncWithAuth(pattern string, handler func(http.ResponseWriter, *http.Request), opts ...RouteOption) {
 	if s.jwtMiddleware == nil {
 		panic("JWT middleware not configured. Call SetJWTMiddleware first.")
 	}
-	s.mux.Handle(pattern, LoggerMiddleware(RecoverMiddleware(CORSMiddleware(s.jwtMiddleware.Middleware(http.HandlerFunc(handler))))))
+	s.mux.Handle(pattern, LoggerMiddleware(RecoverMiddleware(CORSMiddleware(s.jwtMiddleware.Middleware(s.jwtMiddleware.Authorize(http.HandlerFunc(handler), opts...))))))
+}
+
+// HandleFuncWithOptionalAuth registers a public route that also serves
+// authenticated users differently, e.g. showing them their own drafts. The
+// handler reads the claims with GetUserClaims when there are any.
+func (s *Server) HandleFuncWithOptionalAuth(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	if s.jwtMiddleware == nil {
+		panic("JWT middleware not configured. Call SetJWTMiddleware first.")
+	}
+	s.mux.Handle(pattern, LoggerMiddleware(RecoverMiddleware(CORSMiddleware(s.jwtMiddleware.OptionalMiddleware(http.HandlerFunc(handler))))))
 }
 
 func (s *Server) SetJWTMiddleware(jwtMiddleware *JWTMiddleware) {
@@ -117,7 +128,7 @@ func LoggerMiddleware(next http.Handler) http.Handler {
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Access-Control-Allow-Origin", "*")
-		w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
+		w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, PATCH, DELETE")
 
 		if r.Method == "OPTIONS" {
 			http.Error(w, "No Content", http.StatusNoContent)
@@ -151,3 +162,13 @@ func JSON(w http.ResponseWriter, statusCode int, data any) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// ClientIP returns the IP address of the connection the request came from.
+// Forwarding headers are ignored since any client can set them.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -2,65 +2,520 @@ package user
 
 import (
 	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/password"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
 )
 
 type User struct {
-	ID        uuid.UUID    `json:"id"`
-	Username  string       `json:"username"`
-	Email     string       `json:"email"`
-	Password  string       `json:"-"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
-	DeletedAt sql.NullTime `json:"-"`
+	ID              uuid.UUID    `json:"id"`
+	Username        string       `json:"username"`
+	Email           string       `json:"email"`
+	Password        string       `json:"-"`
+	TokenVersion    int          `json:"-"`
+	EmailVerifiedAt sql.NullTime `json:"-"`
+	Role            policy.Role  `json:"role"`
+	TOTPEnabledAt   sql.NullTime `json:"-"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	DeletedAt       sql.NullTime `json:"-"`
 }
 
 type RegisterRequest struct {
 	Username string `json:"username" example:"johndoe"`
 	Email    string `json:"email" example:"john@example.com"`
-	Password string `json:"password" example:"password123"`
+	Password string `json:"password" example:"Str0ng-Passw0rd"`
+}
+
+// Codes of the violations specific to users, the password policy also uses
+// validation.CodeTooShort.
+const (
+	CodeUsernameReserved        = "RESERVED"
+	CodePasswordTooCommon       = "TOO_COMMON"
+	CodePasswordMatchesUsername = "MATCHES_USERNAME"
+	CodePasswordMatchesEmail    = "MATCHES_EMAIL"
+)
+
+// Lengths of the users columns.
+const (
+	MaxUsernameLength = 50
+	maxEmailLength    = 255
+)
+
+// usernamePattern allows letters of any script, the usernames are compared
+// in their normalized form, but no spaces or symbols.
+var usernamePattern = regexp.MustCompile(`^[\p{L}\p{M}\p{N}._-]+$`)
+
+// reservedUsername is taken by the /api/v1/users/me routes, a profile with
+// it could never be read at /api/v1/users/{username}.
+const reservedUsername = "me"
+
+func validateUsername(v *validation.Validator, username string) {
+	v.MaxLength("username", username, MaxUsernameLength)
+	v.Pattern("username", username, usernamePattern, "must only contain letters, digits, dots, dashes and underscores")
+	v.Check(normalize.Identifier(username) != reservedUsername, "username", CodeUsernameReserved, "is reserved")
+}
+
+func validateEmail(v *validation.Validator, email string) {
+	v.MaxLength("email", email, maxEmailLength)
+	v.Email("email", email)
 }
 
 func (r RegisterRequest) Validate() error {
-	if r.Username == "" || r.Email == "" || r.Password == "" {
-		return ErrInvalidInput
-	}
-	return nil
+	v := validation.Validator{}
+	v.Required("username", r.Username)
+	validateUsername(&v, r.Username)
+	v.Required("email", r.Email)
+	validateEmail(&v, r.Email)
+	v.Required("password", r.Password)
+	validateNewPassword(&v, "password", r.Password)
+	return v.Err()
+}
+
+// validateNewPassword checks what the password hasher needs of a password,
+// the password policy is checked by the service.
+func validateNewPassword(v *validation.Validator, field, value string) {
+	v.Check(len(value) <= password.MaxLength, field, validation.CodeTooLong, fmt.Sprintf("must be at most %d bytes", password.MaxLength))
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" example:"john@example.com"`
-	Password string `json:"password" example:"password123"`
+	Password string `json:"password" example:"Str0ng-Passw0rd"`
 }
 
 func (r LoginRequest) Validate() error {
-	if r.Email == "" || r.Password == "" {
-		return ErrInvalidInput
-	}
-	return nil
+	v := validation.Validator{}
+	v.Required("email", r.Email)
+	v.Required("password", r.Password)
+	return v.Err()
+}
+
+// LoginResult holds the tokens of a completed login, or the challenge to
+// complete when the account has two-factor authentication enabled.
+type LoginResult struct {
+	Auth      AuthResponse
+	Challenge *TwoFactorChallenge
+}
+
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required" example:"true"`
+	ChallengeToken    string    `json:"challenge_token" example:"Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
+	ExpiresAt         time.Time `json:"expires_at" example:"2024-01-01T00:05:00Z"`
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" example:"Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
+	// Code is either the current code of the authenticator app or one of
+	// the recovery codes.
+	Code string `json:"code" example:"123456"`
+}
+
+func (r LoginTwoFactorRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("challenge_token", r.ChallengeToken)
+	v.Required("code", r.Code)
+	return v.Err()
 }
 
 type AuthResponse struct {
 	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	User  User   `json:"user"`
+	// RefreshToken is left out when the client keeps using the one it has.
+	RefreshToken string `json:"refresh_token,omitempty" example:"Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
+	User         User   `json:"user"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" example:"Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
+}
+
+func (r RefreshRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("refresh_token", r.RefreshToken)
+	return v.Err()
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty" example:"Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"john@example.com"`
+}
+
+func (r ForgotPasswordRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("email", r.Email)
+	return v.Err()
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" example:"Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
+	NewPassword string `json:"new_password" example:"N3w-Str0ng-Passw0rd"`
+}
+
+func (r ResetPasswordRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("token", r.Token)
+	v.Required("new_password", r.NewPassword)
+	validateNewPassword(&v, "new_password", r.NewPassword)
+	return v.Err()
+}
+
+// UpdateProfileRequest changes the username and/or the email, an empty field
+// keeps the current value.
+type UpdateProfileRequest struct {
+	Username string `json:"username,omitempty" example:"johndoe"`
+	Email    string `json:"email,omitempty" example:"john@example.com"`
+}
+
+func (r UpdateProfileRequest) Validate() error {
+	v := validation.Validator{}
+	if r.Username == "" && r.Email == "" {
+		v.Check(false, "username", validation.CodeRequired, "is required when email is empty")
+		v.Check(false, "email", validation.CodeRequired, "is required when username is empty")
+	}
+	validateUsername(&v, r.Username)
+	validateEmail(&v, r.Email)
+	return v.Err()
+}
+
+// UpdatePublicProfileRequest replaces the profile shown to other users, an
+// empty field clears it.
+type UpdatePublicProfileRequest struct {
+	DisplayName string `json:"display_name" example:"John Doe"`
+	Bio         string `json:"bio" example:"Writing about Go and databases."`
+	AvatarURL   string `json:"avatar_url" example:"https://example.com/avatars/johndoe.png"`
+}
+
+const (
+	maxDisplayNameLength = 100
+	maxBioLength         = 500
+	maxAvatarURLLength   = 2048
+)
+
+func (r UpdatePublicProfileRequest) Validate() error {
+	v := validation.Validator{}
+	v.MaxLength("display_name", r.DisplayName, maxDisplayNameLength)
+	v.MaxLength("bio", r.Bio, maxBioLength)
+	v.MaxLength("avatar_url", r.AvatarURL, maxAvatarURLLength)
+	if r.AvatarURL != "" {
+		u, err := url.Parse(r.AvatarURL)
+		v.Check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"avatar_url", validation.CodeInvalidFormat, "must be an http or https URL")
+	}
+	return v.Err()
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"Str0ng-Passw0rd"`
+	NewPassword     string `json:"new_password" example:"N3w-Str0ng-Passw0rd"`
+}
+
+func (r ChangePasswordRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("current_password", r.CurrentPassword)
+	v.Required("new_password", r.NewPassword)
+	validateNewPassword(&v, "new_password", r.NewPassword)
+	return v.Err()
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" example:"Str0ng-Passw0rd"`
+}
+
+func (r DeleteAccountRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("password", r.Password)
+	return v.Err()
+}
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+func (r ConfirmTwoFactorRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("code", r.Code)
+	return v.Err()
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" example:"Str0ng-Passw0rd"`
+}
+
+func (r DisableTwoFactorRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("password", r.Password)
+	return v.Err()
+}
+
+// TwoFactorSetup is shown once when the user starts enrolling, the secret is
+// usually scanned from a QR code of the otpauth URI.
+type TwoFactorSetup struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/Simple%20Blog:john@example.com?algorithm=SHA1&digits=6&issuer=Simple+Blog&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// RecoveryCodes are shown once when two-factor authentication is enabled,
+// each one can replace a code of the authenticator app for a single login.
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"abcd-efgh-ijkl-mnop"`
+}
+
+// TOTP is the user's authenticator app enrollment. The secret is set when
+// the enrollment starts and EnabledAt once the user confirmed it.
+type TOTP struct {
+	Secret    string
+	EnabledAt sql.NullTime
+	LastStep  sql.NullInt64
+}
+
+type RecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type LoginChallenge struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+// UserIdentity links a user to their account at an OpenID Connect provider.
+type UserIdentity struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// OIDCLoginState is kept between the redirect to the provider and the
+// callback, only the state's hash is stored.
+type OIDCLoginState struct {
+	ID           uuid.UUID
+	Provider     string
+	StateHash    string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+type UpdateRoleRequest struct {
+	Role policy.Role `json:"role" example:"moderator"`
+}
+
+func (r UpdateRoleRequest) Validate() error {
+	v := validation.Validator{}
+	v.Check(r.Role.Valid(), "role", validation.CodeInvalidFormat, "must be one of user, moderator or admin")
+	return v.Err()
+}
+
+// PasswordResetToken is a single-use token sent by email to let a user choose
+// a new password. Only its hash is stored.
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+// EmailVerificationToken proves that the user can read emails sent to Email.
+// The address is stored with the token so a link stops working once the user
+// switches to another address.
+type EmailVerificationToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+// RevokedToken marks an access token, identified by its jti claim, as no
+// longer valid before it expires.
+type RevokedToken struct {
+	JTI       uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// RefreshToken is an opaque, single-use token that can be exchanged for a new
+// access token. Every rotation stays in the same family, so a replayed token
+// can revoke all of its descendants at once.
+type RefreshToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	TokenHash  string
+	ExpiresAt  time.Time
+	RevokedAt  sql.NullTime
+	ReplacedBy uuid.NullUUID
+	CreatedAt  time.Time
+}
+
+// PersonalAccessToken is a long-lived token for scripts and CI. It acts as
+// its owner, but only on routes that accept one of its scopes. Only its hash
+// is stored.
+// ClientInfo describes where a login comes from, it is kept on the session.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// Session is one login of a user, shared by every token pair rotated from it.
+// Its ID is the family ID of the refresh tokens and the `sid` claim of the
+// access tokens.
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	RevokedAt  sql.NullTime
+}
+
+func (s Session) ToItem(currentSessionID string) SessionItem {
+	return SessionItem{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		Current:    s.ID.String() == currentSessionID,
+	}
+}
+
+type SessionItem struct {
+	ID         uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IPAddress  string    `json:"ip_address" example:"192.0.2.1"`
+	CreatedAt  time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	LastSeenAt time.Time `json:"last_seen_at" example:"2024-01-01T00:00:00Z"`
+	// Current marks the session of the access token used for the request.
+	Current bool `json:"current" example:"true"`
+}
+
+type PersonalAccessToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	TokenHash  string
+	Scopes     []policy.Scope
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	CreatedAt  time.Time
+}
+
+func (t PersonalAccessToken) ToItem() PersonalAccessTokenItem {
+	item := PersonalAccessTokenItem{
+		ID:        t.ID,
+		Name:      t.Name,
+		Scopes:    t.Scopes,
+		CreatedAt: t.CreatedAt,
+	}
+	if t.ExpiresAt.Valid {
+		item.ExpiresAt = &t.ExpiresAt.Time
+	}
+	if t.LastUsedAt.Valid {
+		item.LastUsedAt = &t.LastUsedAt.Time
+	}
+	return item
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	Name   string         `json:"name" example:"CI publisher"`
+	Scopes []policy.Scope `json:"scopes" example:"posts:write"`
+	// ExpiresAt is optional, the token never expires without it.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2027-01-01T00:00:00Z"`
+}
+
+const maxPersonalAccessTokenNameLength = 100
+
+func (r CreatePersonalAccessTokenRequest) Validate() error {
+	v := validation.Validator{}
+	v.Required("name", r.Name)
+	v.MaxLength("name", r.Name, maxPersonalAccessTokenNameLength)
+	v.Check(len(r.Scopes) > 0, "scopes", validation.CodeRequired, "is required")
+	for _, scope := range r.Scopes {
+		v.Check(scope.Valid(), "scopes", validation.CodeInvalidFormat, fmt.Sprintf("%q is not a known scope", scope))
+	}
+	if r.ExpiresAt != nil {
+		v.Check(r.ExpiresAt.After(time.Now()), "expires_at", validation.CodeInvalidFormat, "must be in the future")
+	}
+	return v.Err()
+}
+
+type PersonalAccessTokenItem struct {
+	ID         uuid.UUID      `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string         `json:"name" example:"CI publisher"`
+	Scopes     []policy.Scope `json:"scopes" example:"posts:write"`
+	ExpiresAt  *time.Time     `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+	LastUsedAt *time.Time     `json:"last_used_at" example:"2024-01-01T00:00:00Z"`
+	CreatedAt  time.Time      `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// CreatedPersonalAccessToken is only returned when the token is created, the
+// token itself cannot be retrieved afterwards.
+type CreatedPersonalAccessToken struct {
+	PersonalAccessTokenItem
+	Token string `json:"token" example:"pat_Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0..."`
 }
 
 type UserProfile struct {
-	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Username  string    `json:"username" example:"johndoe"`
-	Email     string    `json:"email" example:"john@example.com"`
-	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID               uuid.UUID   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Username         string      `json:"username" example:"johndoe"`
+	Email            string      `json:"email" example:"john@example.com"`
+	EmailVerified    bool        `json:"email_verified" example:"true"`
+	Role             policy.Role `json:"role" example:"user"`
+	TwoFactorEnabled bool        `json:"two_factor_enabled" example:"false"`
+	CreatedAt        time.Time   `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt        time.Time   `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 }
 
 func (u *User) ToUserProfile() UserProfile {
 	return UserProfile{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:               u.ID,
+		Username:         u.Username,
+		Email:            u.Email,
+		EmailVerified:    u.EmailVerifiedAt.Valid,
+		Role:             u.Role,
+		TwoFactorEnabled: u.TOTPEnabledAt.Valid,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
 	}
 }
+
+// PublicProfile is what anyone can see about a user, without the email or
+// anything else about the account.
+type PublicProfile struct {
+	Username    string    `json:"username" example:"johndoe"`
+	DisplayName string    `json:"display_name" example:"John Doe"`
+	Bio         string    `json:"bio" example:"Writing about Go and databases."`
+	AvatarURL   string    `json:"avatar_url" example:"https://example.com/avatars/johndoe.png"`
+	JoinedAt    time.Time `json:"joined_at" example:"2024-01-01T00:00:00Z"`
+	// PostCount only counts published posts.
+	PostCount int `json:"post_count" example:"12"`
+}
@@ -1,13 +1,41 @@
 package user
 
 import (
+	"net/http"
+
 	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
 )
 
 var (
-	ErrUserNotFound       = appError.New("USER_NOT_FOUND", "User not found")
-	ErrInvalidCredentials = appError.New("INVALID_CREDENTIALS", "Invalid email or password")
-	ErrEmailExists        = appError.New("EMAIL_EXISTS", "Email already exists")
-	ErrUsernameExists     = appError.New("USERNAME_EXISTS", "Username already exists")
-	ErrInvalidInput       = appError.New("INVALID_INPUT", "Invalid input data")
+	ErrUserNotFound             = appError.New(http.StatusUnauthorized, "USER_NOT_FOUND", "User not found")
+	ErrInvalidCredentials       = appError.New(http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid email or password")
+	ErrEmailExists              = appError.New(http.StatusConflict, "EMAIL_EXISTS", "Email already exists")
+	ErrUsernameExists           = appError.New(http.StatusConflict, "USERNAME_EXISTS", "Username already exists")
+	ErrInvalidInput             = appError.New(http.StatusUnprocessableEntity, "INVALID_INPUT", "Invalid input data")
+	ErrInvalidRefreshToken      = appError.New(http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "Invalid or expired refresh token")
+	ErrRefreshTokenReused       = appError.New(http.StatusUnauthorized, "REFRESH_TOKEN_REUSED", "Refresh token has already been used")
+	ErrTokenRevoked             = appError.New(http.StatusUnauthorized, "TOKEN_REVOKED", "Token has been revoked")
+	ErrIncorrectPassword        = appError.New(http.StatusForbidden, "INCORRECT_PASSWORD", "Password is incorrect")
+	ErrInvalidResetToken        = appError.New(http.StatusBadRequest, "INVALID_RESET_TOKEN", "Invalid or expired password reset token")
+	ErrInvalidVerificationToken = appError.New(http.StatusBadRequest, "INVALID_VERIFICATION_TOKEN", "Invalid or expired email verification token")
+	ErrEmailAlreadyVerified     = appError.New(http.StatusConflict, "EMAIL_ALREADY_VERIFIED", "Email is already verified")
+	ErrForbidden                = appError.New(http.StatusForbidden, "FORBIDDEN", "You are not authorized to perform this action")
+	ErrTargetUserNotFound       = appError.New(http.StatusNotFound, "TARGET_USER_NOT_FOUND", "User not found")
+	ErrCannotChangeOwnRole      = appError.New(http.StatusForbidden, "CANNOT_CHANGE_OWN_ROLE", "You cannot change your own role")
+	ErrInvalidAccessToken       = appError.New(http.StatusUnauthorized, "INVALID_ACCESS_TOKEN", "Invalid, expired or revoked personal access token")
+	ErrAccessTokenNotFound      = appError.New(http.StatusNotFound, "ACCESS_TOKEN_NOT_FOUND", "Personal access token not found")
+	ErrTooManyRequests          = appError.New(http.StatusTooManyRequests, "TOO_MANY_REQUESTS", "Too many requests, please try again later")
+	ErrTwoFactorAlreadyEnabled  = appError.New(http.StatusConflict, "TWO_FACTOR_ALREADY_ENABLED", "Two-factor authentication is already enabled")
+	ErrTwoFactorNotSetUp        = appError.New(http.StatusConflict, "TWO_FACTOR_NOT_SET_UP", "Two-factor authentication has not been set up")
+	ErrTwoFactorNotEnabled      = appError.New(http.StatusConflict, "TWO_FACTOR_NOT_ENABLED", "Two-factor authentication is not enabled")
+	ErrIncorrectTwoFactorCode   = appError.New(http.StatusForbidden, "INCORRECT_TWO_FACTOR_CODE", "Two-factor code is incorrect")
+	ErrInvalidTwoFactorCode     = appError.New(http.StatusUnauthorized, "INVALID_TWO_FACTOR_CODE", "Invalid two-factor or recovery code")
+	ErrInvalidLoginChallenge    = appError.New(http.StatusUnauthorized, "INVALID_LOGIN_CHALLENGE", "Invalid or expired login challenge")
+	ErrUnknownProvider          = appError.New(http.StatusNotFound, "UNKNOWN_PROVIDER", "Login provider not found")
+	ErrInvalidOIDCState         = appError.New(http.StatusBadRequest, "INVALID_OIDC_STATE", "Invalid or expired login state, please start the login again")
+	ErrOIDCLoginFailed          = appError.New(http.StatusUnauthorized, "OIDC_LOGIN_FAILED", "Login with the provider failed")
+	ErrProviderEmailNotVerified = appError.New(http.StatusForbidden, "PROVIDER_EMAIL_NOT_VERIFIED", "The provider did not return a verified email")
+	ErrAccountNotLinkable       = appError.New(http.StatusConflict, "ACCOUNT_NOT_LINKABLE", "An account with this email exists but its email is not verified, log in with the password and verify it first")
+	ErrProfileNotFound          = appError.New(http.StatusNotFound, "PROFILE_NOT_FOUND", "User not found")
+	ErrSessionNotFound          = appError.New(http.StatusNotFound, "SESSION_NOT_FOUND", "Session not found")
 )
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Change the current user's password. The current password is required. Every session is signed out and a new token pair is returned.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.ChangePasswordRequest  true  "Current and new password"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.AuthResponse}  "Password changed successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}              "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}              "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}              "Current password is incorrect"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}              "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}              "Internal server error"
+// @Router       /api/v1/users/me/password [put]
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	res, err := h.service.ChangePassword(r.Context(), userID, req, clientInfo(r))
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Password changed successfully",
+		Result:  res,
+	})
+}
@@ -0,0 +1,145 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
+)
+
+func changePassword(t *testing.T, accessToken string, request user.ChangePasswordRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/password", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestChangePassword_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	oldToken := registerUser(t, "changepw", "changepw@example.com")
+
+	rec := changePassword(t, oldToken, user.ChangePasswordRequest{
+		CurrentPassword: "Str0ng-Passw0rd",
+		NewPassword:     "newpassword456",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	newToken := response.Result.(map[string]any)["token"].(string)
+	getCurrentUser(t, newToken)
+
+	// Only the new password works
+	if rec := login(t, "changepw@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old password to be rejected, got %d", rec.Code)
+	}
+	if rec := login(t, "changepw@example.com", "newpassword456"); rec.Code != http.StatusOK {
+		t.Errorf("Expected new password to be accepted, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	// Existing sessions are signed out
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+oldToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old access token to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestChangePassword_IncorrectCurrentPassword(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "wrongpw", "wrongpw@example.com")
+
+	rec := changePassword(t, accessToken, user.ChangePasswordRequest{
+		CurrentPassword: "wrongpassword",
+		NewPassword:     "newpassword456",
+	})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	if rec := login(t, "wrongpw@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected password to be unchanged, got %d", rec.Code)
+	}
+}
+
+func TestChangePassword_PasswordPolicy(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "weakpw", "weakpw@example.com")
+
+	rec := changePassword(t, accessToken, user.ChangePasswordRequest{
+		CurrentPassword: "Str0ng-Passw0rd",
+		NewPassword:     "qwerty123",
+	})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expected := []validation.Violation{
+		{Field: "new_password", Code: user.CodePasswordTooCommon, Message: "is too common, choose one that is harder to guess"},
+	}
+	if !slices.Equal(response.Details, expected) {
+		t.Errorf("Expected details %v, got %v", expected, response.Details)
+	}
+
+	if rec := login(t, "weakpw@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected password to be unchanged, got %d", rec.Code)
+	}
+}
+
+func TestChangePassword_InvalidInput(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "invalidpw", "invalidpw@example.com")
+
+	tests := []struct {
+		name    string
+		request user.ChangePasswordRequest
+	}{
+		{
+			name:    "Empty current password",
+			request: user.ChangePasswordRequest{CurrentPassword: "", NewPassword: "newpassword456"},
+		},
+		{
+			name:    "Empty new password",
+			request: user.ChangePasswordRequest{CurrentPassword: "Str0ng-Passw0rd", NewPassword: ""},
+		},
+		{
+			name:    "New password over 72 bytes",
+			request: user.ChangePasswordRequest{CurrentPassword: "Str0ng-Passw0rd", NewPassword: strings.Repeat("Str0ng-", 11)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := changePassword(t, accessToken, tt.request)
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
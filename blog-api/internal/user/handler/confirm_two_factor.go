@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ConfirmTwoFactor godoc
+// @Summary      Confirm two-factor authentication
+// @Description  Enable two-factor authentication with a first code of the authenticator app. The returned recovery codes are only shown once.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.ConfirmTwoFactorRequest  true  "Code of the authenticator app"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.RecoveryCodes}  "Two-factor authentication enabled"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}               "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}               "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}               "Incorrect code"
+// @Failure      409      {object}  server.APIResponse{message=string,error=string}               "Two-factor authentication not set up or already enabled"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}               "Validation error"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}               "Internal server error"
+// @Router       /api/v1/users/me/2fa/confirm [post]
+func (h *Handler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.ConfirmTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	codes, err := h.service.ConfirmTwoFactor(r.Context(), userID, req)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Two-factor authentication enabled",
+		Result:  codes,
+	})
+}
@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/totp"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func confirmTwoFactor(t *testing.T, accessToken, code string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.ConfirmTwoFactorRequest{Code: code})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/2fa/confirm", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+// totpCode returns the authenticator app code for the current time step
+// shifted by offset steps.
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+
+	code, err := totp.Code(secret, totp.Step(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	return code
+}
+
+type twoFactorEnrollment struct {
+	secret        string
+	confirmCode   string
+	recoveryCodes []string
+}
+
+// enableTwoFactor sets up and confirms two-factor authentication for the
+// user of the access token.
+func enableTwoFactor(t *testing.T, accessToken string) twoFactorEnrollment {
+	t.Helper()
+
+	secret := twoFactorSecretFrom(t, setupTwoFactor(t, accessToken))
+	code := totpCode(t, secret, 0)
+
+	rec := confirmTwoFactor(t, accessToken, code)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Two-factor confirmation failed: %s", rec.Body.String())
+	}
+
+	var response struct {
+		Result user.RecoveryCodes `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	return twoFactorEnrollment{
+		secret:        secret,
+		confirmCode:   code,
+		recoveryCodes: response.Result.RecoveryCodes,
+	}
+}
+
+func TestConfirmTwoFactor_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+	secret := twoFactorSecretFrom(t, setupTwoFactor(t, accessToken))
+
+	rec := confirmTwoFactor(t, accessToken, totpCode(t, secret, 0))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	codes := response.Result.(map[string]any)["recovery_codes"].([]any)
+	if len(codes) != 10 {
+		t.Errorf("Expected 10 recovery codes, got %d", len(codes))
+	}
+	if getCurrentUser(t, accessToken)["two_factor_enabled"] != true {
+		t.Error("Expected two-factor authentication to be enabled")
+	}
+}
+
+func TestConfirmTwoFactor_IncorrectCode(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+	secret := twoFactorSecretFrom(t, setupTwoFactor(t, accessToken))
+
+	// A code far outside the accepted clock drift
+	rec := confirmTwoFactor(t, accessToken, totpCode(t, secret, 10))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	if getCurrentUser(t, accessToken)["two_factor_enabled"] != false {
+		t.Error("Expected two-factor authentication to stay disabled")
+	}
+}
+
+func TestConfirmTwoFactor_NotSetUp(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+
+	rec := confirmTwoFactor(t, accessToken, "123456")
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
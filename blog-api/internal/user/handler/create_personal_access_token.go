@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// CreatePersonalAccessToken godoc
+// @Summary      Create a personal access token
+// @Description  Create a long-lived token for scripts and integrations, limited to the given scopes (posts:write, comments:write). The token is only shown in this response.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.CreatePersonalAccessTokenRequest  true  "Token name, scopes and optional expiry"
+// @Success      201      {object}  server.APIResponse{message=string,result=user.CreatedPersonalAccessToken}  "Access token created successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}                            "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}                            "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}                            "Personal access tokens cannot create tokens"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}                            "Validation error"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}                            "Internal server error"
+// @Router       /api/v1/users/me/tokens [post]
+func (h *Handler) CreatePersonalAccessToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.CreatePersonalAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	token, err := h.service.CreatePersonalAccessToken(r.Context(), userID, req)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusCreated, server.APIResponse{
+		Message: "Access token created successfully",
+		Result:  token,
+	})
+}
@@ -0,0 +1,144 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func createAccessToken(t *testing.T, token string, request user.CreatePersonalAccessTokenRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/tokens", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+// accessTokenFrom returns the ID and the token of a created access token.
+func accessTokenFrom(t *testing.T, rec *httptest.ResponseRecorder) (string, string) {
+	t.Helper()
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create access token: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	result := response.Result.(map[string]any)
+	return result["id"].(string), result["token"].(string)
+}
+
+func TestCreatePersonalAccessToken_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	token := registerUser(t, "tokenuser", "token@example.com")
+
+	rec := createAccessToken(t, token, user.CreatePersonalAccessTokenRequest{
+		Name:   "CI publisher",
+		Scopes: []policy.Scope{policy.ScopePostsWrite},
+	})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	result := response.Result.(map[string]any)
+	if result["name"] != "CI publisher" {
+		t.Errorf("Expected name 'CI publisher', got '%v'", result["name"])
+	}
+	accessToken, _ := result["token"].(string)
+	if !strings.HasPrefix(accessToken, server.PersonalAccessTokenPrefix) {
+		t.Errorf("Expected token with prefix %q, got %q", server.PersonalAccessTokenPrefix, accessToken)
+	}
+	if result["expires_at"] != nil {
+		t.Errorf("Expected no expiry, got '%v'", result["expires_at"])
+	}
+
+	// Access tokens cannot be used on routes that do not require a scope
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	// Nor to create more access tokens
+	rec = createAccessToken(t, accessToken, user.CreatePersonalAccessTokenRequest{
+		Name:   "Another token",
+		Scopes: []policy.Scope{policy.ScopePostsWrite},
+	})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreatePersonalAccessToken_InvalidToken(t *testing.T) {
+	cleanupUsers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/tokens", nil)
+	req.Header.Set("Authorization", "Bearer "+server.PersonalAccessTokenPrefix+"not-a-real-token")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreatePersonalAccessToken_InvalidInput(t *testing.T) {
+	cleanupUsers(t)
+
+	token := registerUser(t, "tokenuser", "token@example.com")
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		request user.CreatePersonalAccessTokenRequest
+	}{
+		{
+			name:    "Empty name",
+			request: user.CreatePersonalAccessTokenRequest{Name: "", Scopes: []policy.Scope{policy.ScopePostsWrite}},
+		},
+		{
+			name:    "No scopes",
+			request: user.CreatePersonalAccessTokenRequest{Name: "script"},
+		},
+		{
+			name:    "Unknown scope",
+			request: user.CreatePersonalAccessTokenRequest{Name: "script", Scopes: []policy.Scope{"users:delete"}},
+		},
+		{
+			name:    "Expiry in the past",
+			request: user.CreatePersonalAccessTokenRequest{Name: "script", Scopes: []policy.Scope{policy.ScopePostsWrite}, ExpiresAt: &past},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := createAccessToken(t, token, tt.request)
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
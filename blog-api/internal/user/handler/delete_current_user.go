@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// DeleteCurrentUser godoc
+// @Summary      Delete current user
+// @Description  Delete the current user's account after confirming the password. Every session is signed out and the username and email can be registered again. Depending on the server configuration, the user's posts and comments are either deleted or kept under a "deleted user" placeholder.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.DeleteAccountRequest  true  "Password confirmation"
+// @Success      200      {object}  server.APIResponse{message=string}               "Account deleted successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}  "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}  "Password is incorrect"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}  "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/users/me [delete]
+func (h *Handler) DeleteCurrentUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.DeleteAccount(r.Context(), userID, req); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Account deleted successfully",
+	})
+}
@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func deleteCurrentUser(t *testing.T, accessToken, password string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.DeleteAccountRequest{Password: password})
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDeleteCurrentUser_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "deleteuser", "delete@example.com")
+
+	rec := deleteCurrentUser(t, accessToken, "Str0ng-Passw0rd")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// The account can no longer be used
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected access token to be rejected, got %d", rec.Code)
+	}
+	if rec := login(t, "delete@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected login to be rejected, got %d", rec.Code)
+	}
+
+	// The username and email are free again
+	newToken := registerUser(t, "deleteuser", "delete@example.com")
+	if getCurrentUser(t, newToken)["username"] != "deleteuser" {
+		t.Error("Expected the username to be reused")
+	}
+}
+
+func TestDeleteCurrentUser_IncorrectPassword(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "keepuser", "keep@example.com")
+
+	rec := deleteCurrentUser(t, accessToken, "wrongpassword")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	getCurrentUser(t, accessToken)
+}
+
+func TestDeleteCurrentUser_InvalidInput(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "nopassword", "nopassword@example.com")
+
+	rec := deleteCurrentUser(t, accessToken, "")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// DisableTwoFactor godoc
+// @Summary      Disable two-factor authentication
+// @Description  Remove the authenticator app and the recovery codes after confirming the current password
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.DisableTwoFactorRequest                     true  "Current password"
+// @Success      200      {object}  server.APIResponse{message=string}               "Two-factor authentication disabled"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}  "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}  "Incorrect password"
+// @Failure      409      {object}  server.APIResponse{message=string,error=string}  "Two-factor authentication not enabled"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}  "Validation error"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/users/me/2fa [delete]
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.DisableTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.DisableTwoFactor(r.Context(), userID, req); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Two-factor authentication disabled",
+	})
+}
@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func disableTwoFactor(t *testing.T, accessToken, password string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.DisableTwoFactorRequest{Password: password})
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/2fa", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDisableTwoFactor_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+	enableTwoFactor(t, accessToken)
+
+	rec := disableTwoFactor(t, accessToken, "Str0ng-Passw0rd")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	if getCurrentUser(t, accessToken)["two_factor_enabled"] != false {
+		t.Error("Expected two-factor authentication to be disabled")
+	}
+	if rec := login(t, "totp@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected login to complete in one step, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDisableTwoFactor_IncorrectPassword(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+	enableTwoFactor(t, accessToken)
+
+	rec := disableTwoFactor(t, accessToken, "wrongpassword")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	if getCurrentUser(t, accessToken)["two_factor_enabled"] != true {
+		t.Error("Expected two-factor authentication to stay enabled")
+	}
+}
+
+func TestDisableTwoFactor_NotEnabled(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+
+	rec := disableTwoFactor(t, accessToken, "Str0ng-Passw0rd")
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ForgotPassword godoc
+// @Summary      Request a password reset
+// @Description  Send a password reset link to the email if it belongs to an account. The response is the same whether the email is registered or not.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      user.ForgotPasswordRequest  true  "Account email"
+// @Success      200      {object}  server.APIResponse{message=string}               "Password reset requested"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}  "Invalid request body"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}  "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/auth/password/forgot [post]
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req user.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.ForgotPassword(r.Context(), req); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "If the email is registered, a password reset link has been sent",
+	})
+}
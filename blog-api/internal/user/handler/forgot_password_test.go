@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func forgotPassword(t *testing.T, email string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.ForgotPasswordRequest{Email: email})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/password/forgot", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestForgotPassword_SendsEmail(t *testing.T) {
+	cleanupUsers(t)
+
+	registerBody := user.RegisterRequest{
+		Username: "forgotuser",
+		Email:    "forgot@example.com",
+		Password: "Str0ng-Passw0rd",
+	}
+	body, _ := json.Marshal(registerBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Registration failed: %s", rec.Body.String())
+	}
+
+	rec = forgotPassword(t, "forgot@example.com")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	msg := testMailer.lastMessageTo(t, "forgot@example.com")
+	if token := tokenFromMessage(t, msg); token == "" {
+		t.Error("Expected reset token in email")
+	}
+}
+
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	cleanupUsers(t)
+
+	// Unknown emails get the same response so accounts cannot be enumerated
+	rec := forgotPassword(t, "nobody@example.com")
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
+func TestForgotPassword_SendFails(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "unluckyuser", "unlucky@example.com")
+	testMailer.failSends(t, errors.New("smtp: connection refused"))
+
+	// A failed send is answered like an unknown email, or it would tell
+	// registered emails apart
+	rec := forgotPassword(t, "unlucky@example.com")
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
+func TestForgotPassword_InvalidInput(t *testing.T) {
+	rec := forgotPassword(t, "")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
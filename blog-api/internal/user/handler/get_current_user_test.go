@@ -18,7 +18,7 @@ func TestGetCurrentUser_Success(t *testing.T) {
 	registerBody := user.RegisterRequest{
 		Username: "meuser",
 		Email:    "me@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// GetUserProfile godoc
+// @Summary      Get user profile
+// @Description  Get the public profile of a user, visible to anyone
+// @Tags         users
+// @Produce      json
+// @Param        username  path      string  true  "Username"
+// @Success      200       {object}  server.APIResponse{message=string,result=user.PublicProfile}  "User retrieved successfully"
+// @Failure      404       {object}  server.APIResponse{message=string,error=string}               "User not found"
+// @Failure      500       {object}  server.APIResponse{message=string,error=string}               "Internal server error"
+// @Router       /api/v1/users/{username} [get]
+func (h *Handler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.service.GetPublicProfile(r.Context(), r.PathValue("username"))
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "User retrieved successfully",
+		Result:  profile,
+	})
+}
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func getUserProfile(t *testing.T, username string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+username, nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetUserProfile_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "publicuser", "public@example.com")
+	if rec := updatePublicProfile(t, accessToken, user.UpdatePublicProfileRequest{
+		DisplayName: "Public User",
+		Bio:         "Writes about Go.",
+		AvatarURL:   "https://example.com/avatar.png",
+	}); rec.Code != http.StatusOK {
+		t.Fatalf("Profile update failed: %s", rec.Body.String())
+	}
+
+	// The user service has no post routes, so the posts are inserted directly
+	userID := getCurrentUser(t, accessToken)["id"].(string)
+	for _, slug := range []string{"first-post", "second-post", "deleted-post"} {
+		_, err := testPool.Exec(context.Background(),
+			"INSERT INTO posts (id, title, slug, content, author_id, status, published_at) VALUES ($1, $2, $2, $2, $3, 'published', NOW())",
+			uuid.Must(uuid.NewV7()), slug, userID,
+		)
+		if err != nil {
+			t.Fatalf("Failed to create post: %v", err)
+		}
+	}
+	// Drafts are not counted
+	_, err := testPool.Exec(context.Background(),
+		"INSERT INTO posts (id, title, slug, content, author_id) VALUES ($1, 'draft-post', 'draft-post', 'draft-post', $2)",
+		uuid.Must(uuid.NewV7()), userID,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if _, err := testPool.Exec(context.Background(), "UPDATE posts SET deleted_at = NOW() WHERE slug = 'deleted-post'"); err != nil {
+		t.Fatalf("Failed to delete post: %v", err)
+	}
+
+	rec := getUserProfile(t, "publicuser")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Result map[string]any `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	profile := response.Result
+	if profile["username"] != "publicuser" {
+		t.Errorf("Expected username 'publicuser', got '%v'", profile["username"])
+	}
+	if profile["display_name"] != "Public User" {
+		t.Errorf("Expected display name 'Public User', got '%v'", profile["display_name"])
+	}
+	if profile["bio"] != "Writes about Go." {
+		t.Errorf("Expected bio 'Writes about Go.', got '%v'", profile["bio"])
+	}
+	if profile["avatar_url"] != "https://example.com/avatar.png" {
+		t.Errorf("Expected avatar URL, got '%v'", profile["avatar_url"])
+	}
+	if profile["post_count"] != float64(2) {
+		t.Errorf("Expected post count 2, got %v", profile["post_count"])
+	}
+	if _, ok := profile["joined_at"]; !ok {
+		t.Error("Expected joined_at in profile")
+	}
+	for _, private := range []string{"email", "id", "role"} {
+		if _, ok := profile[private]; ok {
+			t.Errorf("Expected %s not to be public", private)
+		}
+	}
+}
+
+func TestGetUserProfile_NotFound(t *testing.T) {
+	cleanupUsers(t)
+
+	rec := getUserProfile(t, "nobody")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "PROFILE_NOT_FOUND" {
+		t.Errorf("Expected error 'PROFILE_NOT_FOUND', got '%v'", response.Error)
+	}
+}
+
+func TestGetUserProfile_DeletedUser(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "goneuser", "gone@example.com")
+	if rec := deleteCurrentUser(t, accessToken, "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Fatalf("Account deletion failed: %s", rec.Body.String())
+	}
+
+	rec := getUserProfile(t, "goneuser")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
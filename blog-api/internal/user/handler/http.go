@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user/service"
@@ -18,24 +19,45 @@ func New(svc *service.Service) *Handler {
 	}
 }
 
-func (h *Handler) SetupRoutes(server *server.Server) {
+func (h *Handler) SetupRoutes(srv *server.Server) {
 	// Public routes (no auth required)
-	server.HandleFunc("POST /api/v1/auth/register", h.Register)
-	server.HandleFunc("POST /api/v1/auth/login", h.Login)
+	srv.HandleFunc("POST /api/v1/auth/register", h.Register)
+	srv.HandleFunc("POST /api/v1/auth/login", h.Login)
+	srv.HandleFunc("POST /api/v1/auth/login/2fa", h.LoginTwoFactor)
+	srv.HandleFunc("POST /api/v1/auth/refresh", h.Refresh)
+	srv.HandleFunc("POST /api/v1/auth/password/forgot", h.ForgotPassword)
+	srv.HandleFunc("POST /api/v1/auth/password/reset", h.ResetPassword)
+	srv.HandleFunc("GET /api/v1/auth/verify", h.VerifyEmail)
+	srv.HandleFunc("GET /api/v1/auth/oidc/{provider}", h.OIDCLogin)
+	srv.HandleFunc("GET /api/v1/auth/oidc/{provider}/callback", h.OIDCCallback)
+	srv.HandleFunc("GET /api/v1/users/{username}", h.GetUserProfile)
 
 	// Protected routes (auth required)
-	server.HandleFuncWithAuth("GET /api/v1/users/me", h.GetCurrentUser)
+	srv.HandleFuncWithAuth("POST /api/v1/auth/logout", h.Logout)
+	srv.HandleFuncWithAuth("POST /api/v1/auth/verify/resend", h.ResendVerification)
+	srv.HandleFuncWithAuth("GET /api/v1/users/me", h.GetCurrentUser)
+	srv.HandleFuncWithAuth("PATCH /api/v1/users/me", h.UpdateCurrentUser)
+	srv.HandleFuncWithAuth("DELETE /api/v1/users/me", h.DeleteCurrentUser)
+	srv.HandleFuncWithAuth("PUT /api/v1/users/me/profile", h.UpdatePublicProfile)
+	srv.HandleFuncWithAuth("PUT /api/v1/users/me/password", h.ChangePassword)
+	srv.HandleFuncWithAuth("POST /api/v1/users/me/2fa", h.SetupTwoFactor)
+	srv.HandleFuncWithAuth("POST /api/v1/users/me/2fa/confirm", h.ConfirmTwoFactor)
+	srv.HandleFuncWithAuth("DELETE /api/v1/users/me/2fa", h.DisableTwoFactor)
+	srv.HandleFuncWithAuth("POST /api/v1/users/me/tokens", h.CreatePersonalAccessToken)
+	srv.HandleFuncWithAuth("GET /api/v1/users/me/tokens", h.ListPersonalAccessTokens)
+	srv.HandleFuncWithAuth("DELETE /api/v1/users/me/tokens/{tokenId}", h.RevokePersonalAccessToken)
+	srv.HandleFuncWithAuth("GET /api/v1/users/me/sessions", h.ListSessions)
+	srv.HandleFuncWithAuth("DELETE /api/v1/users/me/sessions", h.RevokeOtherSessions)
+	srv.HandleFuncWithAuth("DELETE /api/v1/users/me/sessions/{sessionId}", h.RevokeSession)
+
+	// Admin routes
+	srv.HandleFuncWithAuth("PUT /api/v1/admin/users/{userId}/role", h.UpdateUserRole, server.RequireRole(policy.RoleAdmin))
 }
 
-func (h *Handler) handleError(w http.ResponseWriter, err error) {
-	switch err {
-	case user.ErrInvalidInput:
-		server.ErrorResponse(w, http.StatusUnprocessableEntity, "", err)
-	case user.ErrEmailExists, user.ErrUsernameExists:
-		server.ErrorResponse(w, http.StatusConflict, "", err)
-	case user.ErrInvalidCredentials, user.ErrUserNotFound:
-		server.ErrorResponse(w, http.StatusUnauthorized, "", err)
-	default:
-		server.ErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+// clientInfo describes the client a session is started from.
+func clientInfo(r *http.Request) user.ClientInfo {
+	return user.ClientInfo{
+		IPAddress: server.ClientIP(r),
+		UserAgent: r.UserAgent(),
 	}
 }
@@ -9,7 +9,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,7 +23,11 @@ import (
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/lockout"
 	"github.com/fikryfahrezy/forward/blog-api/internal/logger"
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc"
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc/oidctest"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user/handler"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user/repository"
@@ -32,14 +38,28 @@ var (
 	testPool    *pgxpool.Pool
 	testHandler *handler.Handler
 	testServer  *server.Server
+	testMailer  *recordingMailer
+	testOIDC    *oidctest.Server
 )
 
 const (
-	testJWTSecret   = "test-secret-key-for-integration-tests"
-	testTokenExpiry = 24 * time.Hour
-	testDBUser      = "test"
-	testDBPassword  = "test"
-	testDBName      = "testdb"
+	testJWTSecret         = "test-secret-key-for-integration-tests"
+	testTokenExpiry       = 24 * time.Hour
+	testRefreshExpiry     = 7 * 24 * time.Hour
+	testRevocationTTL     = time.Minute
+	testResetExpiry       = time.Hour
+	testVerifyExpiry      = 24 * time.Hour
+	testResendLimit       = 3
+	testChallengeExpiry   = 5 * time.Minute
+	testPasswordMinLength = 8
+	testBackoffAfter      = 3
+	testLockoutAfter      = 5
+	// Every test request comes from the same address unless it sets its own
+	// RemoteAddr, so the IP limit leaves room for the failures of other tests.
+	testIPLockoutAfter = 20
+	testDBUser         = "test"
+	testDBPassword     = "test"
+	testDBName         = "testdb"
 )
 
 func TestMain(m *testing.M) {
@@ -102,6 +122,9 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Could not run migrations: %s", err)
 	}
 
+	// Start the OpenID Connect provider users log in with
+	testOIDC = oidctest.NewServer("test-client", "test-secret")
+
 	// Setup handler
 	setupTestHandler()
 
@@ -109,6 +132,7 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 
 	// Cleanup
+	testOIDC.Close()
 	testPool.Close()
 	if err := pool.Purge(resource); err != nil {
 		log.Fatalf("Could not purge resource: %s", err)
@@ -186,12 +210,53 @@ func runMigrations(databaseURL string) error {
 
 func setupTestHandler() {
 	logger.NewLogger(logger.Config{}, io.Discard)
+	testJWTKeys := server.NewHMACKeySet(testJWTSecret)
+	testMailer = &recordingMailer{}
 	repo := repository.New(testPool)
-	svc := service.New(server.NewJWTGenerator(testJWTSecret, testTokenExpiry), repo)
+	svc := service.New(
+		service.Config{
+			RefreshTokenDuration:       testRefreshExpiry,
+			RevocationCacheTTL:         testRevocationTTL,
+			PasswordResetTokenDuration: testResetExpiry,
+			PasswordResetURL:           "http://localhost:3000/reset-password",
+			VerificationTokenDuration:  testVerifyExpiry,
+			VerificationURL:            "http://localhost:8080/api/v1/auth/verify",
+			VerificationResendLimit:    testResendLimit,
+			TOTPIssuer:                 "Simple Blog",
+			LoginChallengeDuration:     testChallengeExpiry,
+			OIDCProviders: []*oidc.Provider{
+				oidc.NewProvider(testOIDC.Config("mock", "http://localhost:8080/api/v1/auth/oidc/mock/callback"), nil),
+			},
+			OIDCStateDuration: time.Minute,
+			PasswordMinLength: testPasswordMinLength,
+		},
+		server.NewJWTGenerator(server.JWTGeneratorConfig{
+			Keys:          testJWTKeys,
+			TokenDuration: testTokenExpiry,
+		}),
+		repo,
+		testMailer,
+		lockout.New(lockout.Config{
+			Account: lockout.Limits{
+				BackoffAfter: testBackoffAfter,
+				LockoutAfter: testLockoutAfter,
+			},
+			IP: lockout.Limits{
+				LockoutAfter: testIPLockoutAfter,
+			},
+			BaseDelay:       time.Minute,
+			LockoutDuration: time.Hour,
+			FailureWindow:   time.Hour,
+		}, lockout.NewMemoryStore()),
+	)
 	testHandler = handler.New(svc)
 
 	testServer = server.New(server.Config{Host: "localhost", Port: 8080})
-	testServer.SetJWTMiddleware(server.NewJWTMiddleware(server.JWTConfig{SecretKey: testJWTSecret}))
+	testServer.SetJWTMiddleware(server.NewJWTMiddleware(server.JWTConfig{
+		Keys:                 testJWTKeys,
+		TokenValidator:       svc,
+		PersonalAccessTokens: svc,
+	}))
 	testHandler.SetupRoutes(testServer)
 }
 
@@ -202,3 +267,66 @@ func cleanupUsers(t *testing.T) {
 		t.Fatalf("Failed to cleanup users: %v", err)
 	}
 }
+
+// recordingMailer keeps every sent email in memory so tests can read the
+// tokens that would have been emailed to the user.
+type recordingMailer struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+	// err fails every send when set.
+	err error
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// failSends makes every send fail with err until the test ends.
+func (m *recordingMailer) failSends(t *testing.T, err error) {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.err = nil
+	})
+}
+
+// lastMessageTo returns the latest email sent to the given address.
+func (m *recordingMailer) lastMessageTo(t *testing.T, to string) mailer.Message {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To == to {
+			return m.messages[i]
+		}
+	}
+	t.Fatalf("No email sent to %s", to)
+	return mailer.Message{}
+}
+
+// tokenFromMessage extracts the `token` query param of the link in an email.
+func tokenFromMessage(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+
+	match := regexp.MustCompile(`[?&]token=([A-Za-z0-9_-]+)`).FindStringSubmatch(msg.Body)
+	if match == nil {
+		t.Fatalf("No token found in email: %s", msg.Body)
+	}
+	return match[1]
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// ListPersonalAccessTokens godoc
+// @Summary      List personal access tokens
+// @Description  List the current user's active personal access tokens, newest first. The tokens themselves are never returned.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  server.APIResponse{message=string,result=[]user.PersonalAccessTokenItem}  "Access tokens retrieved successfully"
+// @Failure      401  {object}  server.APIResponse{message=string,error=string}                          "Unauthorized"
+// @Failure      403  {object}  server.APIResponse{message=string,error=string}                          "Personal access tokens cannot list tokens"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}                          "Internal server error"
+// @Router       /api/v1/users/me/tokens [get]
+func (h *Handler) ListPersonalAccessTokens(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	tokens, err := h.service.ListPersonalAccessTokens(r.Context(), userID)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Access tokens retrieved successfully",
+		Result:  tokens,
+	})
+}
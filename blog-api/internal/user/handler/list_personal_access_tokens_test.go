@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func listAccessTokens(t *testing.T, token string) []any {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/tokens", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result.([]any)
+}
+
+func TestListPersonalAccessTokens_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	token := registerUser(t, "tokenuser", "token@example.com")
+	otherToken := registerUser(t, "otheruser", "other@example.com")
+
+	accessTokenFrom(t, createAccessToken(t, token, user.CreatePersonalAccessTokenRequest{
+		Name:   "first",
+		Scopes: []policy.Scope{policy.ScopePostsWrite},
+	}))
+	accessTokenFrom(t, createAccessToken(t, token, user.CreatePersonalAccessTokenRequest{
+		Name:   "second",
+		Scopes: []policy.Scope{policy.ScopePostsWrite, policy.ScopeCommentsWrite},
+	}))
+	accessTokenFrom(t, createAccessToken(t, otherToken, user.CreatePersonalAccessTokenRequest{
+		Name:   "not mine",
+		Scopes: []policy.Scope{policy.ScopePostsWrite},
+	}))
+
+	tokens := listAccessTokens(t, token)
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 access tokens, got %d", len(tokens))
+	}
+
+	newest := tokens[0].(map[string]any)
+	if newest["name"] != "second" {
+		t.Errorf("Expected newest token first, got '%v'", newest["name"])
+	}
+	if _, ok := newest["token"]; ok {
+		t.Error("Expected the token itself not to be listed")
+	}
+	if scopes := newest["scopes"].([]any); len(scopes) != 2 {
+		t.Errorf("Expected 2 scopes, got %v", scopes)
+	}
+}
+
+func TestListPersonalAccessTokens_Unauthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/tokens", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// ListSessions godoc
+// @Summary      List sessions
+// @Description  List the devices the current user is signed in on, most recently used first. The session the request was made with is marked as current.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  server.APIResponse{message=string,result=[]user.SessionItem}  "Sessions retrieved successfully"
+// @Failure      401  {object}  server.APIResponse{message=string,error=string}              "Unauthorized"
+// @Failure      403  {object}  server.APIResponse{message=string,error=string}              "Personal access tokens cannot list sessions"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}              "Internal server error"
+// @Router       /api/v1/users/me/sessions [get]
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), claims)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Sessions retrieved successfully",
+		Result:  sessions,
+	})
+}
@@ -0,0 +1,151 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+type sessionTokens struct {
+	token        string
+	refreshToken string
+}
+
+func loginWithDevice(t *testing.T, remoteAddr, userAgent, email string) sessionTokens {
+	t.Helper()
+
+	body, _ := json.Marshal(user.LoginRequest{Email: email, Password: "Str0ng-Passw0rd"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Login failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse login response: %v", err)
+	}
+	result := response.Result.(map[string]any)
+	return sessionTokens{
+		token:        result["token"].(string),
+		refreshToken: result["refresh_token"].(string),
+	}
+}
+
+func listSessions(t *testing.T, token string) []map[string]any {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/sessions", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	sessions := []map[string]any{}
+	for _, s := range response.Result.([]any) {
+		sessions = append(sessions, s.(map[string]any))
+	}
+	return sessions
+}
+
+func currentUserStatus(t *testing.T, token string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestListSessions_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "sessionuser", "session@example.com")
+	registerUser(t, "othersession", "othersession@example.com")
+
+	laptop := loginWithDevice(t, "192.0.2.10:1234", "Laptop Browser", "session@example.com")
+	loginWithDevice(t, "192.0.2.11:1234", "Phone App", "session@example.com")
+	loginWithDevice(t, "192.0.2.12:1234", "Not Mine", "othersession@example.com")
+
+	sessions := listSessions(t, laptop.token)
+	// The registration started a session too
+	if len(sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d", len(sessions))
+	}
+
+	var current map[string]any
+	for _, s := range sessions {
+		if s["user_agent"] == "Not Mine" {
+			t.Error("Expected only the user's own sessions")
+		}
+		if s["current"] == true {
+			if current != nil {
+				t.Error("Expected a single current session")
+			}
+			current = s
+		}
+	}
+	if current == nil {
+		t.Fatal("Expected the current session to be marked")
+	}
+	if current["user_agent"] != "Laptop Browser" {
+		t.Errorf("Expected user agent 'Laptop Browser', got '%v'", current["user_agent"])
+	}
+	if current["ip_address"] != "192.0.2.10" {
+		t.Errorf("Expected IP address '192.0.2.10', got '%v'", current["ip_address"])
+	}
+}
+
+func TestListSessions_KeepsSessionAcrossRefresh(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "refreshsession", "refreshsession@example.com")
+	phone := loginWithDevice(t, "192.0.2.20:1234", "Phone App", "refreshsession@example.com")
+
+	rec := refresh(t, phone.refreshToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Refresh failed: %s", rec.Body.String())
+	}
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	refreshed := response.Result.(map[string]any)["token"].(string)
+
+	sessions := listSessions(t, refreshed)
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(sessions))
+	}
+	for _, s := range sessions {
+		if (s["user_agent"] == "Phone App") != (s["current"] == true) {
+			t.Errorf("Expected the refreshed token to belong to the phone session, got %v", s)
+		}
+	}
+}
+
+func TestListSessions_Unauthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/sessions", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
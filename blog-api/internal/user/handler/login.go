@@ -2,39 +2,73 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/lockout"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
 // Login godoc
 // @Summary      Login user
-// @Description  Authenticate user and return JWT token
+// @Description  Authenticate user and return JWT token. Accounts with two-factor authentication get a challenge token instead, to complete on /api/v1/auth/login/2fa. Repeated failures for the same account or from the same IP are throttled.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body      user.LoginRequest  true  "Login credentials"
-// @Success      200      {object}  server.APIResponse{message=string,result=user.AuthResponse}  "Login successful"
-// @Failure      400      {object}  server.APIResponse{message=string,error=string}              "Invalid input"
-// @Failure      401      {object}  server.APIResponse{message=string,error=string}              "Invalid credentials"
-// @Failure      500      {object}  server.APIResponse{message=string,error=string}              "Internal server error"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.AuthResponse}        "Login successful"
+// @Success      202      {object}  server.APIResponse{message=string,result=user.TwoFactorChallenge}  "Two-factor authentication required"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}                    "Invalid input"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}                    "Invalid credentials"
+// @Failure      429      {object}  server.APIResponse{message=string,error=string}                    "Too many failed attempts, see the Retry-After header"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}                    "Internal server error"
 // @Router       /api/v1/auth/login [post]
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	var req user.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
 		return
 	}
 
-	u, err := h.service.Login(r.Context(), req)
+	result, err := h.service.Login(r.Context(), req, clientInfo(r))
+	if lockedOut(w, r, err) {
+		return
+	}
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
+		return
+	}
+
+	writeLoginResult(w, result)
+}
+
+func writeLoginResult(w http.ResponseWriter, result user.LoginResult) {
+	if result.Challenge != nil {
+		server.JSON(w, http.StatusAccepted, server.APIResponse{
+			Message: "Two-factor authentication required",
+			Result:  result.Challenge,
+		})
 		return
 	}
 
 	server.JSON(w, http.StatusOK, server.APIResponse{
 		Message: "Login successful",
-		Result:  u,
+		Result:  result.Auth,
 	})
 }
+
+// lockedOut responds with 429 and a Retry-After header when err means the
+// login is throttled.
+func lockedOut(w http.ResponseWriter, r *http.Request, err error) bool {
+	var locked *lockout.LockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+	server.ErrorResponse(w, r, http.StatusTooManyRequests, "", user.ErrTooManyRequests)
+	return true
+}
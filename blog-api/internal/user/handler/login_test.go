@@ -2,11 +2,17 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
@@ -18,7 +24,7 @@ func TestLogin_Success(t *testing.T) {
 	registerBody := user.RegisterRequest{
 		Username: "loginuser",
 		Email:    "login@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
@@ -33,7 +39,7 @@ func TestLogin_Success(t *testing.T) {
 	// Now login
 	loginBody := user.LoginRequest{
 		Email:    "login@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ = json.Marshal(loginBody)
 	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
@@ -64,6 +70,41 @@ func TestLogin_Success(t *testing.T) {
 	}
 }
 
+func TestLogin_EmailIgnoresCase(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "caseuser", "Case.User@Example.com")
+
+	for _, email := range []string{"case.user@example.com", "CASE.USER@EXAMPLE.COM", " Case.User@Example.com "} {
+		if rec := login(t, email, "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+			t.Errorf("Expected login as %q to succeed, got %d. Body: %s", email, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestLogin_AccountBackoffIgnoresCase(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "casebackoff", "casebackoff@example.com")
+
+	// Changing the casing of the email does not reset the count
+	for i := 1; i <= testBackoffAfter; i++ {
+		email := "casebackoff@example.com"
+		if i%2 == 0 {
+			email = "CaseBackoff@Example.com"
+		}
+		rec := loginFrom(t, "198.51.100.3:1234", email, "wrongpassword")
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("Attempt %d: expected status %d, got %d. Body: %s", i, http.StatusUnauthorized, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := loginFrom(t, "198.51.100.4:1234", "CASEBACKOFF@EXAMPLE.COM", "Str0ng-Passw0rd")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	}
+}
+
 func TestLogin_InvalidCredentials(t *testing.T) {
 	cleanupUsers(t)
 
@@ -71,7 +112,7 @@ func TestLogin_InvalidCredentials(t *testing.T) {
 	registerBody := user.RegisterRequest{
 		Username: "loginuser",
 		Email:    "login@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
@@ -100,7 +141,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 
 	loginBody := user.LoginRequest{
 		Email:    "nonexistent@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(loginBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
@@ -120,7 +161,7 @@ func TestLogin_InvalidInput(t *testing.T) {
 	}{
 		{
 			name:    "Empty email",
-			request: user.LoginRequest{Email: "", Password: "password123"},
+			request: user.LoginRequest{Email: "", Password: "Str0ng-Passw0rd"},
 		},
 		{
 			name:    "Empty password",
@@ -143,3 +184,100 @@ func TestLogin_InvalidInput(t *testing.T) {
 		})
 	}
 }
+
+func loginFrom(t *testing.T, remoteAddr, email, password string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.LoginRequest{Email: email, Password: password})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLogin_AccountBackoff(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "backoffuser", "backoff@example.com")
+
+	for i := 1; i <= testBackoffAfter; i++ {
+		rec := loginFrom(t, "198.51.100.1:1234", "backoff@example.com", "wrongpassword")
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("Attempt %d: expected status %d, got %d. Body: %s", i, http.StatusUnauthorized, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Even the right password is refused while the account is throttled, from
+	// any address
+	rec := loginFrom(t, "198.51.100.2:1234", "backoff@example.com", "Str0ng-Passw0rd")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	}
+
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || retryAfter <= 0 {
+		t.Errorf("Expected a positive Retry-After header, got %q", rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestLogin_IPLockout(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "iplockuser", "iplock@example.com")
+
+	// Spread the failures over many accounts so no single account is throttled
+	for i := 1; i <= testIPLockoutAfter; i++ {
+		email := fmt.Sprintf("guess%d@example.com", i)
+		rec := loginFrom(t, "203.0.113.9:1234", email, "Str0ng-Passw0rd")
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("Attempt %d: expected status %d, got %d. Body: %s", i, http.StatusUnauthorized, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := loginFrom(t, "203.0.113.9:1234", "iplock@example.com", "Str0ng-Passw0rd")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header")
+	}
+
+	// Other addresses are not affected
+	if rec := loginFrom(t, "203.0.113.10:1234", "iplock@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected login from another address to succeed, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLogin_RehashesLegacyPassword(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "legacyuser", "legacy@example.com")
+
+	// A hash from before the switch to argon2id
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte("Str0ng-Passw0rd"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if _, err := testPool.Exec(context.Background(), "UPDATE users SET password = $1 WHERE email = $2", string(legacyHash), "legacy@example.com"); err != nil {
+		t.Fatalf("Failed to set legacy hash: %v", err)
+	}
+
+	if rec := login(t, "legacy@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var storedHash string
+	if err := testPool.QueryRow(context.Background(), "SELECT password FROM users WHERE email = $1", "legacy@example.com").Scan(&storedHash); err != nil {
+		t.Fatalf("Failed to read hash: %v", err)
+	}
+	if !strings.HasPrefix(storedHash, "$argon2id$") {
+		t.Errorf("Expected the hash to be upgraded to argon2id, got %s", storedHash[:min(len(storedHash), 10)])
+	}
+
+	// The upgraded hash still matches the password
+	if rec := login(t, "legacy@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
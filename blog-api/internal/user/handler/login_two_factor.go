@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// LoginTwoFactor godoc
+// @Summary      Complete two-factor login
+// @Description  Exchange the challenge token of the login step and a code of the authenticator app, or a recovery code, for the JWT token. Wrong codes are throttled like wrong passwords.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      user.LoginTwoFactorRequest  true  "Challenge token and code"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.AuthResponse}  "Login successful"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}              "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}              "Invalid challenge or code"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}              "Validation error"
+// @Failure      429      {object}  server.APIResponse{message=string,error=string}              "Too many failed attempts, see the Retry-After header"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}              "Internal server error"
+// @Router       /api/v1/auth/login/2fa [post]
+func (h *Handler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req user.LoginTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	u, err := h.service.LoginTwoFactor(r.Context(), req, clientInfo(r))
+	if lockedOut(w, r, err) {
+		return
+	}
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Login successful",
+		Result:  u,
+	})
+}
@@ -0,0 +1,154 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func loginTwoFactor(t *testing.T, remoteAddr, challengeToken, code string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.LoginTwoFactorRequest{ChallengeToken: challengeToken, Code: code})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/2fa", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+// loginChallenge runs the password step of a two-factor account and returns
+// the challenge token.
+func loginChallenge(t *testing.T, email string) string {
+	t.Helper()
+
+	rec := login(t, email, "Str0ng-Passw0rd")
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	result := response.Result.(map[string]any)
+	if result["two_factor_required"] != true {
+		t.Errorf("Expected two_factor_required, got %v", result["two_factor_required"])
+	}
+	if _, ok := result["token"]; ok {
+		t.Error("Expected no access token before the second step")
+	}
+	return result["challenge_token"].(string)
+}
+
+func TestLoginTwoFactor_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+	enrollment := enableTwoFactor(t, accessToken)
+
+	challenge := loginChallenge(t, "totp@example.com")
+
+	// Wrong codes are rejected without consuming the challenge
+	if rec := loginTwoFactor(t, "192.0.2.30:1234", challenge, totpCode(t, enrollment.secret, 10)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected wrong code to be rejected, got %d", rec.Code)
+	}
+
+	// The next step's code is still within the accepted clock drift, and was
+	// not used to confirm the setup
+	rec := loginTwoFactor(t, "192.0.2.30:1234", challenge, totpCode(t, enrollment.secret, 1))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	token, _ := response.Result.(map[string]any)["token"].(string)
+	if getCurrentUser(t, token)["username"] != "totpuser" {
+		t.Error("Expected the access token to belong to the user")
+	}
+
+	// The challenge is single-use
+	if rec := loginTwoFactor(t, "192.0.2.30:1234", challenge, totpCode(t, enrollment.secret, 1)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected reused challenge to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestLoginTwoFactor_ReplayedCode(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "replayuser", "replay@example.com")
+	enrollment := enableTwoFactor(t, accessToken)
+
+	challenge := loginChallenge(t, "replay@example.com")
+
+	rec := loginTwoFactor(t, "192.0.2.31:1234", challenge, enrollment.confirmCode)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestLoginTwoFactor_RecoveryCode(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "recoveryuser", "recovery@example.com")
+	enrollment := enableTwoFactor(t, accessToken)
+	recoveryCode := enrollment.recoveryCodes[0]
+
+	// Recovery codes can be typed without dashes and in upper case
+	typed := strings.ToUpper(strings.ReplaceAll(recoveryCode, "-", ""))
+	rec := loginTwoFactor(t, "192.0.2.32:1234", loginChallenge(t, "recovery@example.com"), typed)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Each recovery code works once
+	rec = loginTwoFactor(t, "192.0.2.32:1234", loginChallenge(t, "recovery@example.com"), recoveryCode)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected used recovery code to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestLoginTwoFactor_InvalidChallenge(t *testing.T) {
+	cleanupUsers(t)
+
+	rec := loginTwoFactor(t, "192.0.2.33:1234", "not-a-real-challenge", "123456")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestLoginTwoFactor_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		request user.LoginTwoFactorRequest
+	}{
+		{
+			name:    "Empty challenge token",
+			request: user.LoginTwoFactorRequest{ChallengeToken: "", Code: "123456"},
+		},
+		{
+			name:    "Empty code",
+			request: user.LoginTwoFactorRequest{ChallengeToken: "some-challenge", Code: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := loginTwoFactor(t, "192.0.2.34:1234", tt.request.ChallengeToken, tt.request.Code)
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
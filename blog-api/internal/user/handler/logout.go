@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// Logout godoc
+// @Summary      Logout user
+// @Description  Revoke the current access token. When a refresh token is given, every token issued from the same login is revoked too.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.LogoutRequest  false  "Refresh token to revoke"
+// @Success      200      {object}  server.APIResponse{message=string}               "Logout successful"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}  "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/auth/logout [post]
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var req user.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), claims, req); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Logout successful",
+	})
+}
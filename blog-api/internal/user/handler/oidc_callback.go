@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// OIDCCallback godoc
+// @Summary      Provider login callback
+// @Description  Complete the login with the provider's authorization code. The provider's account is linked to the user with the same verified email, or a new user is created. Responds like the login endpoint, including the two-factor challenge.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true   "Provider name"
+// @Param        code      query     string  false  "Authorization code"
+// @Param        state     query     string  true   "Login state"
+// @Param        error     query     string  false  "Error returned by the provider"
+// @Success      200       {object}  server.APIResponse{message=string,result=user.AuthResponse}        "Login successful"
+// @Success      202       {object}  server.APIResponse{message=string,result=user.TwoFactorChallenge}  "Two-factor authentication required"
+// @Failure      400       {object}  server.APIResponse{message=string,error=string}                    "Invalid or expired login state"
+// @Failure      401       {object}  server.APIResponse{message=string,error=string}                    "Login with the provider failed"
+// @Failure      403       {object}  server.APIResponse{message=string,error=string}                    "The provider did not return a verified email"
+// @Failure      404       {object}  server.APIResponse{message=string,error=string}                    "Provider not found"
+// @Failure      409       {object}  server.APIResponse{message=string,error=string}                    "An account with the email exists but is not verified"
+// @Failure      500       {object}  server.APIResponse{message=string,error=string}                    "Internal server error"
+// @Router       /api/v1/auth/oidc/{provider}/callback [get]
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("error") != "" {
+		server.HandleError(w, r, user.ErrOIDCLoginFailed)
+		return
+	}
+
+	result, err := h.service.OIDCCallback(r.Context(), r.PathValue("provider"), q.Get("code"), q.Get("state"), clientInfo(r))
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	writeLoginResult(w, result)
+}
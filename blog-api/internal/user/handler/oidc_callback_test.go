@@ -0,0 +1,239 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc/oidctest"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// authorizeAtProvider starts a login with the mock provider as the given
+// identity and returns the callback query the provider redirects back with.
+func authorizeAtProvider(t *testing.T, identity oidctest.Identity) url.Values {
+	t.Helper()
+
+	testOIDC.SetIdentity(identity)
+
+	rec := oidcLogin(t, "mock")
+	if rec.Code != http.StatusFound {
+		t.Fatalf("OIDC login failed: %s", rec.Body.String())
+	}
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := client.Get(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("Failed to authorize at the provider: %v", err)
+	}
+	//nolint:errcheck
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		t.Fatalf("Expected the provider to redirect back, got %d", res.StatusCode)
+	}
+
+	callback, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("Failed to parse callback: %v", err)
+	}
+	return callback.Query()
+}
+
+func oidcCallback(t *testing.T, provider string, query url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/"+provider+"/callback?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func oidcAccessToken(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result.(map[string]any)["token"].(string)
+}
+
+func TestOIDCCallback_CreatesUser(t *testing.T) {
+	cleanupUsers(t)
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "new-subject",
+		Email:         "oidcnew@example.com",
+		EmailVerified: true,
+		Username:      "OIDC.New",
+	})
+	accessToken := oidcAccessToken(t, oidcCallback(t, "mock", query))
+
+	me := getCurrentUser(t, accessToken)
+	if me["email"] != "oidcnew@example.com" {
+		t.Errorf("Expected email 'oidcnew@example.com', got '%v'", me["email"])
+	}
+	if me["username"] != "oidc.new" {
+		t.Errorf("Expected username 'oidc.new', got '%v'", me["username"])
+	}
+	if me["email_verified"] != true {
+		t.Error("Expected the provider's verified email to be verified")
+	}
+
+	// Logging in again with the same identity gets the same user
+	query = authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "new-subject",
+		Email:         "changed@example.com",
+		EmailVerified: true,
+	})
+	again := getCurrentUser(t, oidcAccessToken(t, oidcCallback(t, "mock", query)))
+	if again["id"] != me["id"] {
+		t.Errorf("Expected the same user %v, got %v", me["id"], again["id"])
+	}
+}
+
+func TestOIDCCallback_UsernameTaken(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "taken", "taken@example.com")
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "taken-subject",
+		Email:         "other@example.com",
+		EmailVerified: true,
+		Username:      "taken",
+	})
+	me := getCurrentUser(t, oidcAccessToken(t, oidcCallback(t, "mock", query)))
+	if me["username"] == "taken" {
+		t.Error("Expected a different username than the existing user's")
+	}
+}
+
+func TestOIDCCallback_LinksVerifiedUser(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "linkuser", "link@example.com")
+	token := tokenFromMessage(t, testMailer.lastMessageTo(t, "link@example.com"))
+	if rec := verifyEmail(t, token); rec.Code != http.StatusOK {
+		t.Fatalf("Email verification failed: %s", rec.Body.String())
+	}
+	me := getCurrentUser(t, accessToken)
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "link-subject",
+		Email:         "link@example.com",
+		EmailVerified: true,
+	})
+	linked := getCurrentUser(t, oidcAccessToken(t, oidcCallback(t, "mock", query)))
+	if linked["id"] != me["id"] {
+		t.Errorf("Expected the existing user %v, got %v", me["id"], linked["id"])
+	}
+}
+
+func TestOIDCCallback_UnverifiedLocalAccount(t *testing.T) {
+	cleanupUsers(t)
+
+	// Whoever registered the email never proved they own it, so linking it
+	// would hand the account to them
+	registerUser(t, "squatter", "victim@example.com")
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "victim-subject",
+		Email:         "victim@example.com",
+		EmailVerified: true,
+	})
+	rec := oidcCallback(t, "mock", query)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
+
+func TestOIDCCallback_UnverifiedProviderEmail(t *testing.T) {
+	cleanupUsers(t)
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject: "unverified-subject",
+		Email:   "unverified@example.com",
+	})
+	rec := oidcCallback(t, "mock", query)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+}
+
+func TestOIDCCallback_TwoFactor(t *testing.T) {
+	cleanupUsers(t)
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "totp-subject",
+		Email:         "oidctotp@example.com",
+		EmailVerified: true,
+	})
+	enableTwoFactor(t, oidcAccessToken(t, oidcCallback(t, "mock", query)))
+
+	query = authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "totp-subject",
+		Email:         "oidctotp@example.com",
+		EmailVerified: true,
+	})
+	rec := oidcCallback(t, "mock", query)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if _, ok := response.Result.(map[string]any)["token"]; ok {
+		t.Error("Expected no access token before the second step")
+	}
+}
+
+func TestOIDCCallback_InvalidState(t *testing.T) {
+	cleanupUsers(t)
+
+	query := authorizeAtProvider(t, oidctest.Identity{
+		Subject:       "state-subject",
+		Email:         "state@example.com",
+		EmailVerified: true,
+	})
+
+	tampered := url.Values{"code": {query.Get("code")}, "state": {"not-the-state"}}
+	if rec := oidcCallback(t, "mock", tampered); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown state, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+
+	oidcAccessToken(t, oidcCallback(t, "mock", query))
+
+	// The state is single use
+	if rec := oidcCallback(t, "mock", query); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a reused state, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
+
+func TestOIDCCallback_ProviderError(t *testing.T) {
+	query := url.Values{"error": {"access_denied"}, "state": {"any"}}
+	rec := oidcCallback(t, "mock", query)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestOIDCCallback_UnknownProvider(t *testing.T) {
+	query := url.Values{"code": {"code"}, "state": {"state"}}
+	rec := oidcCallback(t, "unknown", query)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
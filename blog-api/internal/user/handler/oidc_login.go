@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// OIDCLogin godoc
+// @Summary      Login with a provider
+// @Description  Redirect to the OpenID Connect provider's login page. The provider redirects back to the callback endpoint.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path  string  true  "Provider name, as configured in OIDC_PROVIDERS"
+// @Success      302  "Redirect to the provider"
+// @Failure      404  {object}  server.APIResponse{message=string,error=string}  "Provider not found"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/auth/oidc/{provider} [get]
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	authURL, err := h.service.OIDCAuthURL(r.Context(), r.PathValue("provider"))
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
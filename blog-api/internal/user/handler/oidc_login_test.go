@@ -0,0 +1,62 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func oidcLogin(t *testing.T, provider string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/"+provider, nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOIDCLogin_Redirect(t *testing.T) {
+	rec := oidcLogin(t, "mock")
+	if rec.Code != http.StatusFound {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusFound, rec.Code, rec.Body.String())
+	}
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("Failed to parse redirect: %v", err)
+	}
+	if !strings.HasPrefix(location.String(), testOIDC.URL+"/authorize") {
+		t.Fatalf("Expected a redirect to the provider, got %s", location)
+	}
+
+	q := location.Query()
+	if q.Get("client_id") != "test-client" {
+		t.Errorf("Expected client_id 'test-client', got '%s'", q.Get("client_id"))
+	}
+	if q.Get("response_type") != "code" {
+		t.Errorf("Expected response_type 'code', got '%s'", q.Get("response_type"))
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Error("Expected a S256 PKCE code challenge")
+	}
+	for _, param := range []string{"state", "nonce"} {
+		if q.Get(param) == "" {
+			t.Errorf("Expected the %s param", param)
+		}
+	}
+
+	// Every login gets its own state
+	other, _ := url.Parse(oidcLogin(t, "mock").Header().Get("Location"))
+	if other.Query().Get("state") == q.Get("state") {
+		t.Error("Expected a different state for each login")
+	}
+}
+
+func TestOIDCLogin_UnknownProvider(t *testing.T) {
+	rec := oidcLogin(t, "unknown")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// Refresh godoc
+// @Summary      Refresh access token
+// @Description  Exchange a refresh token for a new access token and a rotated refresh token. Reusing a refresh token revokes every token issued from the same login.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      user.RefreshRequest  true  "Refresh token"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.AuthResponse}  "Token refreshed successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}              "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}              "Invalid, expired or reused refresh token"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}              "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}              "Internal server error"
+// @Router       /api/v1/auth/refresh [post]
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req user.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	res, err := h.service.Refresh(r.Context(), req)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Token refreshed successfully",
+		Result:  res,
+	})
+}
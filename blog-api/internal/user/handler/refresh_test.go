@@ -0,0 +1,139 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func registerAndGetRefreshToken(t *testing.T) string {
+	t.Helper()
+
+	registerBody := user.RegisterRequest{
+		Username: "refreshuser",
+		Email:    "refresh@example.com",
+		Password: "Str0ng-Passw0rd",
+	}
+	body, _ := json.Marshal(registerBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Registration failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse register response: %v", err)
+	}
+
+	result := response.Result.(map[string]any)
+	return result["refresh_token"].(string)
+}
+
+func refresh(t *testing.T, refreshToken string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.RefreshRequest{RefreshToken: refreshToken})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRefresh_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	refreshToken := registerAndGetRefreshToken(t)
+
+	rec := refresh(t, refreshToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Message != "Token refreshed successfully" {
+		t.Errorf("Expected message 'Token refreshed successfully', got '%s'", response.Message)
+	}
+
+	result, ok := response.Result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+
+	if _, exists := result["token"]; !exists {
+		t.Error("Expected token in response")
+	}
+	if result["refresh_token"] == refreshToken {
+		t.Error("Expected refresh token to be rotated")
+	}
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	cleanupUsers(t)
+
+	refreshToken := registerAndGetRefreshToken(t)
+
+	rec := refresh(t, refreshToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("First refresh failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	result := response.Result.(map[string]any)
+	rotatedToken := result["refresh_token"].(string)
+	accessToken := result["token"].(string)
+
+	// The access token works before the reuse, which also caches its session
+	if code := currentUserStatus(t, accessToken); code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+	}
+
+	// Replaying the original token is detected as reuse
+	rec = refresh(t, refreshToken)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+
+	// The session is ended, so its access tokens are rejected as well
+	if code := currentUserStatus(t, accessToken); code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, code)
+	}
+
+	// The rotated token belongs to the same family, so it is revoked too
+	rec = refresh(t, rotatedToken)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestRefresh_InvalidToken(t *testing.T) {
+	cleanupUsers(t)
+
+	rec := refresh(t, "not-a-real-refresh-token")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestRefresh_InvalidInput(t *testing.T) {
+	rec := refresh(t, "")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
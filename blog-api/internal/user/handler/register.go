@@ -23,13 +23,13 @@ import (
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	var req user.RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.ErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
 		return
 	}
 
-	u, err := h.service.Register(r.Context(), req)
+	u, err := h.service.Register(r.Context(), req, clientInfo(r))
 	if err != nil {
-		h.handleError(w, err)
+		server.HandleError(w, r, err)
 		return
 	}
 
@@ -5,19 +5,33 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
 )
 
+func registerRequest(t *testing.T, request user.RegisterRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
 func TestRegister_Success(t *testing.T) {
 	cleanupUsers(t)
 
 	reqBody := user.RegisterRequest{
 		Username: "testuser",
 		Email:    "test@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -69,7 +83,7 @@ func TestRegister_DuplicateEmail(t *testing.T) {
 	reqBody := user.RegisterRequest{
 		Username: "testuser1",
 		Email:    "duplicate@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -107,7 +121,7 @@ func TestRegister_DuplicateUsername(t *testing.T) {
 	reqBody := user.RegisterRequest{
 		Username: "duplicateuser",
 		Email:    "test1@example.com",
-		Password: "password123",
+		Password: "Str0ng-Passw0rd",
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -145,16 +159,28 @@ func TestRegister_InvalidInput(t *testing.T) {
 	}{
 		{
 			name:    "Empty username",
-			request: user.RegisterRequest{Username: "", Email: "test@example.com", Password: "password123"},
+			request: user.RegisterRequest{Username: "", Email: "test@example.com", Password: "Str0ng-Passw0rd"},
 		},
 		{
 			name:    "Empty email",
-			request: user.RegisterRequest{Username: "testuser", Email: "", Password: "password123"},
+			request: user.RegisterRequest{Username: "testuser", Email: "", Password: "Str0ng-Passw0rd"},
 		},
 		{
 			name:    "Empty password",
 			request: user.RegisterRequest{Username: "testuser", Email: "test@example.com", Password: ""},
 		},
+		{
+			name:    "Password over 72 bytes",
+			request: user.RegisterRequest{Username: "testuser", Email: "test@example.com", Password: strings.Repeat("Str0ng-", 11)},
+		},
+		{
+			name:    "Reserved username",
+			request: user.RegisterRequest{Username: "me", Email: "test@example.com", Password: "Str0ng-Passw0rd"},
+		},
+		{
+			name:    "Reserved username in another case",
+			request: user.RegisterRequest{Username: "ME", Email: "test@example.com", Password: "Str0ng-Passw0rd"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -172,3 +198,170 @@ func TestRegister_InvalidInput(t *testing.T) {
 		})
 	}
 }
+
+func TestRegister_PasswordPolicy(t *testing.T) {
+	cleanupUsers(t)
+
+	tests := []struct {
+		name     string
+		password string
+		expected []validation.Violation
+	}{
+		{
+			name:     "Too short",
+			password: "Sh0rt-1",
+			expected: []validation.Violation{
+				{Field: "password", Code: validation.CodeTooShort, Message: "must be at least 8 characters"},
+			},
+		},
+		{
+			name:     "Common password",
+			password: "Password123",
+			expected: []validation.Violation{
+				{Field: "password", Code: user.CodePasswordTooCommon, Message: "is too common, choose one that is harder to guess"},
+			},
+		},
+		{
+			name:     "Same as username",
+			password: "PolicyUser",
+			expected: []validation.Violation{
+				{Field: "password", Code: user.CodePasswordMatchesUsername, Message: "must not be the same as the username"},
+			},
+		},
+		{
+			name:     "Same as email",
+			password: "policy@example.com",
+			expected: []validation.Violation{
+				{Field: "password", Code: user.CodePasswordMatchesEmail, Message: "must not be the same as the email"},
+			},
+		},
+		{
+			name:     "Short and common",
+			password: "qwerty",
+			expected: []validation.Violation{
+				{Field: "password", Code: validation.CodeTooShort, Message: "must be at least 8 characters"},
+				{Field: "password", Code: user.CodePasswordTooCommon, Message: "is too common, choose one that is harder to guess"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(user.RegisterRequest{
+				Username: "policyuser",
+				Email:    "policy@example.com",
+				Password: tt.password,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			testServer.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+
+			var response server.APIResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if !slices.Equal(response.Details, tt.expected) {
+				t.Errorf("Expected details %v, got %v", tt.expected, response.Details)
+			}
+		})
+	}
+}
+
+func TestRegister_NormalizedDuplicates(t *testing.T) {
+	cleanupUsers(t)
+
+	rec := registerRequest(t, user.RegisterRequest{
+		Username: "John.Doe",
+		Email:    "John.Doe@Example.com",
+		Password: "Str0ng-Passw0rd",
+	})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("First registration failed: %s", rec.Body.String())
+	}
+
+	// The casing the user typed is kept for display
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	userMap := response.Result.(map[string]any)["user"].(map[string]any)
+	if userMap["username"] != "John.Doe" || userMap["email"] != "John.Doe@Example.com" {
+		t.Errorf("Expected the original casing, got %v", userMap)
+	}
+
+	tests := []struct {
+		name    string
+		request user.RegisterRequest
+		code    string
+	}{
+		{
+			name:    "Email with other casing",
+			request: user.RegisterRequest{Username: "janedoe", Email: "john.doe@example.com", Password: "Str0ng-Passw0rd"},
+			code:    "EMAIL_EXISTS",
+		},
+		{
+			name:    "Email with surrounding spaces",
+			request: user.RegisterRequest{Username: "janedoe", Email: " JOHN.DOE@EXAMPLE.COM ", Password: "Str0ng-Passw0rd"},
+			code:    "EMAIL_EXISTS",
+		},
+		{
+			name:    "Username with other casing",
+			request: user.RegisterRequest{Username: "john.doe", Email: "jane@example.com", Password: "Str0ng-Passw0rd"},
+			code:    "USERNAME_EXISTS",
+		},
+		{
+			name:    "Username with fullwidth letters",
+			request: user.RegisterRequest{Username: "ｊｏｈｎ.ｄｏｅ", Email: "jane@example.com", Password: "Str0ng-Passw0rd"},
+			code:    "USERNAME_EXISTS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := registerRequest(t, tt.request)
+			if rec.Code != http.StatusConflict {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+			}
+
+			var response server.APIResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error != tt.code {
+				t.Errorf("Expected error '%s', got '%v'", tt.code, response.Error)
+			}
+		})
+	}
+}
+
+func TestRegister_ValidationDetails(t *testing.T) {
+	rec := registerRequest(t, user.RegisterRequest{
+		Username: strings.Repeat("a", 50) + " b",
+		Email:    "John Doe <john@example.com>",
+		Password: "",
+	})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	// Every problem is reported at once
+	expected := []validation.Violation{
+		{Field: "username", Code: validation.CodeTooLong, Message: "must be at most 50 characters"},
+		{Field: "username", Code: validation.CodeInvalidFormat, Message: "must only contain letters, digits, dots, dashes and underscores"},
+		{Field: "email", Code: validation.CodeInvalidEmail, Message: "must be a valid email address"},
+		{Field: "password", Code: validation.CodeRequired, Message: "is required"},
+	}
+	if !slices.Equal(response.Details, expected) {
+		t.Errorf("Expected details %v, got %v", expected, response.Details)
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// ResendVerification godoc
+// @Summary      Resend verification email
+// @Description  Send a new email verification link to the current user's email
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  server.APIResponse{message=string}               "Verification email sent"
+// @Failure      401  {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      409  {object}  server.APIResponse{message=string,error=string}  "Email already verified"
+// @Failure      429  {object}  server.APIResponse{message=string,error=string}  "Too many requests"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/auth/verify/resend [post]
+func (h *Handler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	if err := h.service.ResendVerification(r.Context(), userID); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Verification email sent",
+	})
+}
@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func resendVerification(t *testing.T, accessToken string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify/resend", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResendVerification_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "resenduser", "resend@example.com")
+	firstToken := tokenFromMessage(t, testMailer.lastMessageTo(t, "resend@example.com"))
+
+	rec := resendVerification(t, accessToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	secondToken := tokenFromMessage(t, testMailer.lastMessageTo(t, "resend@example.com"))
+	if secondToken == firstToken {
+		t.Fatal("Expected a new verification token to be sent")
+	}
+
+	if rec := verifyEmail(t, secondToken); rec.Code != http.StatusOK {
+		t.Errorf("Expected resent token to verify the email, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestResendVerification_AlreadyVerified(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "verifieduser", "verified@example.com")
+	token := tokenFromMessage(t, testMailer.lastMessageTo(t, "verified@example.com"))
+	if rec := verifyEmail(t, token); rec.Code != http.StatusOK {
+		t.Fatalf("Verify email failed: %s", rec.Body.String())
+	}
+
+	rec := resendVerification(t, accessToken)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
+
+func TestResendVerification_RateLimited(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "limiteduser", "limited@example.com")
+
+	// Registration already sent one of the allowed emails
+	for i := 1; i < testResendLimit; i++ {
+		if rec := resendVerification(t, accessToken); rec.Code != http.StatusOK {
+			t.Fatalf("Resend %d failed: %s", i, rec.Body.String())
+		}
+	}
+
+	rec := resendVerification(t, accessToken)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	}
+}
+
+func TestResendVerification_Unauthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify/resend", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
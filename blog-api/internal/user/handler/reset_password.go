@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password using the token from the password reset email. Every existing session of the user is signed out.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      user.ResetPasswordRequest  true  "Reset token and new password"
+// @Success      200      {object}  server.APIResponse{message=string}               "Password reset successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}  "Invalid request body or reset token"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}  "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/auth/password/reset [post]
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req user.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), req); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Password reset successfully",
+	})
+}
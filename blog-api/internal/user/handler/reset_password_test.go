@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
+)
+
+func resetPassword(t *testing.T, token, newPassword string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.ResetPasswordRequest{Token: token, NewPassword: newPassword})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/password/reset", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func login(t *testing.T, email, password string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.LoginRequest{Email: email, Password: password})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	registerBody := user.RegisterRequest{
+		Username: "resetuser",
+		Email:    "reset@example.com",
+		Password: "Str0ng-Passw0rd",
+	}
+	body, _ := json.Marshal(registerBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Registration failed: %s", rec.Body.String())
+	}
+
+	var registerResponse server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &registerResponse); err != nil {
+		t.Fatalf("Failed to parse register response: %v", err)
+	}
+	result := registerResponse.Result.(map[string]any)
+	oldToken := result["token"].(string)
+	oldRefreshToken := result["refresh_token"].(string)
+
+	if rec := forgotPassword(t, "reset@example.com"); rec.Code != http.StatusOK {
+		t.Fatalf("Forgot password failed: %s", rec.Body.String())
+	}
+	resetToken := tokenFromMessage(t, testMailer.lastMessageTo(t, "reset@example.com"))
+
+	rec = resetPassword(t, resetToken, "newpassword456")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Only the new password works
+	if rec := login(t, "reset@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old password to be rejected, got %d", rec.Code)
+	}
+	if rec := login(t, "reset@example.com", "newpassword456"); rec.Code != http.StatusOK {
+		t.Errorf("Expected new password to be accepted, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	// Existing sessions are signed out
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+oldToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old access token to be rejected, got %d", rec.Code)
+	}
+	if rec := refresh(t, oldRefreshToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old refresh token to be rejected, got %d", rec.Code)
+	}
+
+	// The reset token is single-use
+	if rec := resetPassword(t, resetToken, "anotherpassword789"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected reused reset token to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestResetPassword_PasswordPolicy(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "weakreset", "weakreset@example.com")
+	if rec := forgotPassword(t, "weakreset@example.com"); rec.Code != http.StatusOK {
+		t.Fatalf("Forgot password failed: %s", rec.Body.String())
+	}
+	resetToken := tokenFromMessage(t, testMailer.lastMessageTo(t, "weakreset@example.com"))
+
+	rec := resetPassword(t, resetToken, "weakreset")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expected := []validation.Violation{
+		{Field: "new_password", Code: user.CodePasswordMatchesUsername, Message: "must not be the same as the username"},
+	}
+	if !slices.Equal(response.Details, expected) {
+		t.Errorf("Expected details %v, got %v", expected, response.Details)
+	}
+
+	// The rejected attempt does not use up the token
+	if rec := resetPassword(t, resetToken, "newpassword456"); rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	cleanupUsers(t)
+
+	rec := resetPassword(t, "not-a-real-reset-token", "newpassword456")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
+
+func TestResetPassword_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		request user.ResetPasswordRequest
+	}{
+		{
+			name:    "Empty token",
+			request: user.ResetPasswordRequest{Token: "", NewPassword: "newpassword456"},
+		},
+		{
+			name:    "Empty password",
+			request: user.ResetPasswordRequest{Token: "some-token", NewPassword: ""},
+		},
+		{
+			name:    "Password over 72 bytes",
+			request: user.ResetPasswordRequest{Token: "some-token", NewPassword: strings.Repeat("Str0ng-", 11)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := resetPassword(t, tt.request.Token, tt.request.NewPassword)
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
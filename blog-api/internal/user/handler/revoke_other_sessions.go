@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// RevokeOtherSessions godoc
+// @Summary      Sign out everywhere else
+// @Description  Sign the current user out of every session except the one the request was made with
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  server.APIResponse{message=string}               "Other sessions revoked successfully"
+// @Failure      401  {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      403  {object}  server.APIResponse{message=string,error=string}  "Personal access tokens cannot revoke sessions"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/users/me/sessions [delete]
+func (h *Handler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	if err := h.service.RevokeOtherSessions(r.Context(), claims); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Other sessions revoked successfully",
+	})
+}
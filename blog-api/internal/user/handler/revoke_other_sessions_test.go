@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func revokeOtherSessions(t *testing.T, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/sessions", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRevokeOtherSessions_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	registered := registerUser(t, "everywhere", "everywhere@example.com")
+	laptop := loginWithDevice(t, "192.0.2.50:1234", "Laptop Browser", "everywhere@example.com")
+	phone := loginWithDevice(t, "192.0.2.51:1234", "Phone App", "everywhere@example.com")
+
+	if rec := revokeOtherSessions(t, laptop.token); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	for name, token := range map[string]string{"registration": registered, "phone": phone.token} {
+		if code := currentUserStatus(t, token); code != http.StatusUnauthorized {
+			t.Errorf("Expected %s access token to be rejected, got %d", name, code)
+		}
+	}
+	if rec := refresh(t, phone.refreshToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected phone refresh token to be rejected, got %d", rec.Code)
+	}
+
+	// Only the current session is left, and it keeps working
+	sessions := listSessions(t, laptop.token)
+	if len(sessions) != 1 || sessions[0]["current"] != true {
+		t.Errorf("Expected only the current session to be left, got %v", sessions)
+	}
+	if rec := refresh(t, laptop.refreshToken); rec.Code != http.StatusOK {
+		t.Errorf("Expected current refresh token to keep working, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRevokeOtherSessions_Unauthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/sessions", nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// RevokePersonalAccessToken godoc
+// @Summary      Revoke a personal access token
+// @Description  Revoke one of the current user's personal access tokens, it stops working immediately
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tokenId  path      string                                           true  "Token ID"
+// @Success      200      {object}  server.APIResponse{message=string}               "Access token revoked successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}  "Invalid token ID"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}  "Personal access tokens cannot revoke tokens"
+// @Failure      404      {object}  server.APIResponse{message=string,error=string}  "Access token not found"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/users/me/tokens/{tokenId} [delete]
+func (h *Handler) RevokePersonalAccessToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	tokenID, err := uuid.Parse(r.PathValue("tokenId"))
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid token ID", nil)
+		return
+	}
+
+	if err := h.service.RevokePersonalAccessToken(r.Context(), userID, tokenID); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Access token revoked successfully",
+	})
+}
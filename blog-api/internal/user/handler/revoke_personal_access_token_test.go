@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func revokeAccessToken(t *testing.T, token, tokenID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/tokens/"+tokenID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRevokePersonalAccessToken_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	token := registerUser(t, "tokenuser", "token@example.com")
+	tokenID, accessToken := accessTokenFrom(t, createAccessToken(t, token, user.CreatePersonalAccessTokenRequest{
+		Name:   "script",
+		Scopes: []policy.Scope{policy.ScopePostsWrite},
+	}))
+
+	rec := revokeAccessToken(t, token, tokenID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	if tokens := listAccessTokens(t, token); len(tokens) != 0 {
+		t.Errorf("Expected revoked token not to be listed, got %d tokens", len(tokens))
+	}
+
+	// The revoked token no longer authenticates
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/tokens", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+
+	// Revoking twice reports the token as missing
+	if rec := revokeAccessToken(t, token, tokenID); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
+
+func TestRevokePersonalAccessToken_NotOwner(t *testing.T) {
+	cleanupUsers(t)
+
+	ownerToken := registerUser(t, "tokenuser", "token@example.com")
+	otherToken := registerUser(t, "otheruser", "other@example.com")
+	tokenID, _ := accessTokenFrom(t, createAccessToken(t, ownerToken, user.CreatePersonalAccessTokenRequest{
+		Name:   "script",
+		Scopes: []policy.Scope{policy.ScopePostsWrite},
+	}))
+
+	rec := revokeAccessToken(t, otherToken, tokenID)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+
+	if tokens := listAccessTokens(t, ownerToken); len(tokens) != 1 {
+		t.Errorf("Expected the owner's token to be kept, got %d tokens", len(tokens))
+	}
+}
+
+func TestRevokePersonalAccessToken_InvalidID(t *testing.T) {
+	cleanupUsers(t)
+
+	token := registerUser(t, "tokenuser", "token@example.com")
+
+	rec := revokeAccessToken(t, token, "not-a-uuid")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
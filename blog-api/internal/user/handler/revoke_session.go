@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Sign the current user out of one of their sessions, e.g. a lost device. Its refresh token stops working immediately.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        sessionId  path      string                                           true  "Session ID"
+// @Success      200        {object}  server.APIResponse{message=string}               "Session revoked successfully"
+// @Failure      400        {object}  server.APIResponse{message=string,error=string}  "Invalid session ID"
+// @Failure      401        {object}  server.APIResponse{message=string,error=string}  "Unauthorized"
+// @Failure      403        {object}  server.APIResponse{message=string,error=string}  "Personal access tokens cannot revoke sessions"
+// @Failure      404        {object}  server.APIResponse{message=string,error=string}  "Session not found"
+// @Failure      500        {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/users/me/sessions/{sessionId} [delete]
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	sessionID, err := uuid.Parse(r.PathValue("sessionId"))
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid session ID", nil)
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Session revoked successfully",
+	})
+}
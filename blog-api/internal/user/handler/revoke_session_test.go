@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func revokeSession(t *testing.T, token, sessionID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/sessions/"+sessionID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRevokeSession_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "revokesession", "revokesession@example.com")
+	laptop := loginWithDevice(t, "192.0.2.30:1234", "Laptop Browser", "revokesession@example.com")
+	lost := loginWithDevice(t, "192.0.2.31:1234", "Lost Phone", "revokesession@example.com")
+
+	var lostID string
+	for _, s := range listSessions(t, laptop.token) {
+		if s["user_agent"] == "Lost Phone" {
+			lostID = s["id"].(string)
+		}
+	}
+	if lostID == "" {
+		t.Fatal("Expected the lost phone to be listed")
+	}
+
+	if rec := revokeSession(t, laptop.token, lostID); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// The revoked session is signed out, the current one is not
+	if code := currentUserStatus(t, lost.token); code != http.StatusUnauthorized {
+		t.Errorf("Expected access token of the revoked session to be rejected, got %d", code)
+	}
+	if rec := refresh(t, lost.refreshToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected refresh token of the revoked session to be rejected, got %d", rec.Code)
+	}
+	if code := currentUserStatus(t, laptop.token); code != http.StatusOK {
+		t.Errorf("Expected current session to keep working, got %d", code)
+	}
+	for _, s := range listSessions(t, laptop.token) {
+		if s["id"] == lostID {
+			t.Error("Expected the revoked session not to be listed")
+		}
+	}
+
+	// Revoking it again finds nothing
+	if rec := revokeSession(t, laptop.token, lostID); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
+
+func TestRevokeSession_OtherUsersSession(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "sessionowner", "sessionowner@example.com")
+	registerUser(t, "sessionthief", "sessionthief@example.com")
+	owner := loginWithDevice(t, "192.0.2.40:1234", "Owner Browser", "sessionowner@example.com")
+	thief := loginWithDevice(t, "192.0.2.41:1234", "Thief Browser", "sessionthief@example.com")
+
+	var ownerSessionID string
+	for _, s := range listSessions(t, owner.token) {
+		if s["current"] == true {
+			ownerSessionID = s["id"].(string)
+		}
+	}
+
+	if rec := revokeSession(t, thief.token, ownerSessionID); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+	if code := currentUserStatus(t, owner.token); code != http.StatusOK {
+		t.Errorf("Expected the owner's session to keep working, got %d", code)
+	}
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	cleanupUsers(t)
+
+	token := registerUser(t, "nosession", "nosession@example.com")
+
+	if rec := revokeSession(t, token, uuid.NewString()); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+	if rec := revokeSession(t, token, "not-a-uuid"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// SetupTwoFactor godoc
+// @Summary      Set up two-factor authentication
+// @Description  Generate a TOTP secret and its otpauth URI for an authenticator app. Two-factor authentication is enabled once a code is confirmed on /api/v1/users/me/2fa/confirm.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  server.APIResponse{message=string,result=user.TwoFactorSetup}  "Two-factor setup started"
+// @Failure      401  {object}  server.APIResponse{message=string,error=string}                "Unauthorized"
+// @Failure      409  {object}  server.APIResponse{message=string,error=string}                "Two-factor authentication already enabled"
+// @Failure      500  {object}  server.APIResponse{message=string,error=string}                "Internal server error"
+// @Router       /api/v1/users/me/2fa [post]
+func (h *Handler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	setup, err := h.service.SetupTwoFactor(r.Context(), userID)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Two-factor setup started",
+		Result:  setup,
+	})
+}
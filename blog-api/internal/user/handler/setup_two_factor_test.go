@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+func setupTwoFactor(t *testing.T, accessToken string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/2fa", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+// twoFactorSecretFrom returns the secret of a started two-factor setup.
+func twoFactorSecretFrom(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Two-factor setup failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result.(map[string]any)["secret"].(string)
+}
+
+func TestSetupTwoFactor_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+
+	rec := setupTwoFactor(t, accessToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	result := response.Result.(map[string]any)
+	secret, _ := result["secret"].(string)
+	if secret == "" {
+		t.Fatal("Expected a secret")
+	}
+
+	uri, err := url.Parse(result["otpauth_uri"].(string))
+	if err != nil {
+		t.Fatalf("Failed to parse otpauth URI: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" {
+		t.Errorf("Expected an otpauth://totp URI, got %s", uri)
+	}
+	if uri.Path != "/Simple Blog:totp@example.com" {
+		t.Errorf("Expected the label to name the issuer and the email, got %q", uri.Path)
+	}
+	if uri.Query().Get("secret") != secret {
+		t.Errorf("Expected the URI to carry the secret, got %q", uri.Query().Get("secret"))
+	}
+
+	// Nothing changes until the setup is confirmed
+	if getCurrentUser(t, accessToken)["two_factor_enabled"] != false {
+		t.Error("Expected two-factor authentication to stay disabled before confirmation")
+	}
+	if rec := login(t, "totp@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected login to complete in one step, got %d", rec.Code)
+	}
+}
+
+func TestSetupTwoFactor_AlreadyEnabled(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "totpuser", "totp@example.com")
+	enableTwoFactor(t, accessToken)
+
+	rec := setupTwoFactor(t, accessToken)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
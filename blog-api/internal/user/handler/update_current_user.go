@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdateCurrentUser godoc
+// @Summary      Update current user
+// @Description  Change the current user's username and/or email. Changing the email marks it as unverified, sends a verification email, signs the user out everywhere and returns a new token pair. Otherwise only a new access token is returned, the current refresh token keeps working.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.UpdateProfileRequest  true  "Fields to change"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.AuthResponse}  "Profile updated successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}              "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}              "Unauthorized"
+// @Failure      409      {object}  server.APIResponse{message=string,error=string}              "Email or username already exists"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}              "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}              "Internal server error"
+// @Router       /api/v1/users/me [patch]
+func (h *Handler) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	// Tokens issued before sessions were tracked have none
+	sessionID, _ := uuid.Parse(claims.SessionID)
+
+	var req user.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	res, err := h.service.UpdateProfile(r.Context(), userID, sessionID, req, clientInfo(r))
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Profile updated successfully",
+		Result:  res,
+	})
+}
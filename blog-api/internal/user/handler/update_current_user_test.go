@@ -0,0 +1,215 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func updateCurrentUser(t *testing.T, accessToken string, request user.UpdateProfileRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUpdateCurrentUser_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	oldToken := registerUser(t, "profileuser", "profile@example.com")
+	verifyToken := tokenFromMessage(t, testMailer.lastMessageTo(t, "profile@example.com"))
+	if rec := verifyEmail(t, verifyToken); rec.Code != http.StatusOK {
+		t.Fatalf("Verify email failed: %s", rec.Body.String())
+	}
+
+	rec := updateCurrentUser(t, oldToken, user.UpdateProfileRequest{
+		Username: "renameduser",
+		Email:    "renamed@example.com",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	newToken := response.Result.(map[string]any)["token"].(string)
+
+	profile := getCurrentUser(t, newToken)
+	if profile["username"] != "renameduser" {
+		t.Errorf("Expected username 'renameduser', got %v", profile["username"])
+	}
+	if profile["email"] != "renamed@example.com" {
+		t.Errorf("Expected email 'renamed@example.com', got %v", profile["email"])
+	}
+	if profile["email_verified"] != false {
+		t.Error("Expected the new email to be unverified")
+	}
+
+	// A verification email is sent to the new address
+	testMailer.lastMessageTo(t, "renamed@example.com")
+
+	// Tokens carrying the old claims are rejected
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+oldToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old access token to be rejected, got %d", rec.Code)
+	}
+
+	// The new email is used to log in
+	if rec := login(t, "renamed@example.com", "Str0ng-Passw0rd"); rec.Code != http.StatusOK {
+		t.Errorf("Expected login with the new email to succeed, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUpdateCurrentUser_ChangeCasing(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "casinguser", "casing@example.com")
+	verifyToken := tokenFromMessage(t, testMailer.lastMessageTo(t, "casing@example.com"))
+	if rec := verifyEmail(t, verifyToken); rec.Code != http.StatusOK {
+		t.Fatalf("Verify email failed: %s", rec.Body.String())
+	}
+
+	rec := updateCurrentUser(t, accessToken, user.UpdateProfileRequest{
+		Username: "CasingUser",
+		Email:    "Casing@Example.com",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	profile := getCurrentUser(t, response.Result.(map[string]any)["token"].(string))
+	if profile["username"] != "CasingUser" {
+		t.Errorf("Expected username 'CasingUser', got %v", profile["username"])
+	}
+	if profile["email"] != "Casing@Example.com" {
+		t.Errorf("Expected email 'Casing@Example.com', got %v", profile["email"])
+	}
+	if profile["email_verified"] != true {
+		t.Error("Expected the email to stay verified")
+	}
+}
+
+func TestUpdateCurrentUser_KeepsSessions(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "stayinguser", "staying@example.com")
+	rec := login(t, "staying@example.com", "Str0ng-Passw0rd")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Login failed: %s", rec.Body.String())
+	}
+	var loginResponse server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &loginResponse); err != nil {
+		t.Fatalf("Failed to parse login response: %v", err)
+	}
+	otherToken := loginResponse.Result.(map[string]any)["token"].(string)
+	refreshToken := loginResponse.Result.(map[string]any)["refresh_token"].(string)
+
+	tests := []struct {
+		name     string
+		username string
+	}{
+		{name: "Unchanged", username: "stayinguser"},
+		{name: "New username", username: "renamedstayer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := updateCurrentUser(t, accessToken, user.UpdateProfileRequest{Username: tt.username})
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var response server.APIResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			result := response.Result.(map[string]any)
+			if _, ok := result["refresh_token"]; ok {
+				t.Error("Expected no new refresh token")
+			}
+			if profile := getCurrentUser(t, result["token"].(string)); profile["username"] != tt.username {
+				t.Errorf("Expected username '%s', got %v", tt.username, profile["username"])
+			}
+
+			// No one is signed out
+			getCurrentUser(t, accessToken)
+			getCurrentUser(t, otherToken)
+		})
+	}
+
+	if rec := refresh(t, refreshToken); rec.Code != http.StatusOK {
+		t.Errorf("Expected the refresh token to keep working, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUpdateCurrentUser_Conflict(t *testing.T) {
+	cleanupUsers(t)
+
+	registerUser(t, "takenuser", "taken@example.com")
+	accessToken := registerUser(t, "otheruser", "other@example.com")
+
+	tests := []struct {
+		name    string
+		request user.UpdateProfileRequest
+	}{
+		{
+			name:    "Email taken",
+			request: user.UpdateProfileRequest{Email: "taken@example.com"},
+		},
+		{
+			name:    "Username taken",
+			request: user.UpdateProfileRequest{Username: "takenuser"},
+		},
+		{
+			name:    "Email taken with other casing",
+			request: user.UpdateProfileRequest{Email: " Taken@Example.COM"},
+		},
+		{
+			name:    "Username taken with other casing",
+			request: user.UpdateProfileRequest{Username: "TakenUser"},
+		},
+		{
+			name:    "Username taken with own email",
+			request: user.UpdateProfileRequest{Username: "takenuser", Email: "other@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := updateCurrentUser(t, accessToken, tt.request)
+			if rec.Code != http.StatusConflict {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateCurrentUser_InvalidInput(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "emptyupdate", "emptyupdate@example.com")
+
+	rec := updateCurrentUser(t, accessToken, user.UpdateProfileRequest{})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdatePublicProfile godoc
+// @Summary      Update public profile
+// @Description  Replace the current user's display name, bio and avatar URL shown on their public profile. An empty field clears it.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      user.UpdatePublicProfileRequest  true  "Public profile"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.PublicProfile}  "Profile updated successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}               "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}               "Unauthorized"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}               "Invalid input"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}               "Internal server error"
+// @Router       /api/v1/users/me/profile [put]
+func (h *Handler) UpdatePublicProfile(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.UpdatePublicProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	profile, err := h.service.UpdatePublicProfile(r.Context(), userID, req)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Profile updated successfully",
+		Result:  profile,
+	})
+}
@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func updatePublicProfile(t *testing.T, accessToken string, request user.UpdatePublicProfileRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/profile", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUpdatePublicProfile_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "profileuser", "profile@example.com")
+
+	rec := updatePublicProfile(t, accessToken, user.UpdatePublicProfileRequest{
+		DisplayName: "Profile User",
+		Bio:         "Hello there.",
+		AvatarURL:   "https://example.com/profile.png",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	result := response.Result.(map[string]any)
+	if result["display_name"] != "Profile User" {
+		t.Errorf("Expected display name 'Profile User', got '%v'", result["display_name"])
+	}
+
+	// The session is kept, none of the fields are in the token
+	getCurrentUser(t, accessToken)
+
+	// Empty fields clear the profile
+	rec = updatePublicProfile(t, accessToken, user.UpdatePublicProfileRequest{DisplayName: "Profile User"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	result = response.Result.(map[string]any)
+	if result["bio"] != "" || result["avatar_url"] != "" {
+		t.Errorf("Expected bio and avatar URL to be cleared, got '%v' and '%v'", result["bio"], result["avatar_url"])
+	}
+}
+
+func TestUpdatePublicProfile_InvalidInput(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "badprofile", "badprofile@example.com")
+
+	tests := []struct {
+		name    string
+		request user.UpdatePublicProfileRequest
+	}{
+		{
+			name:    "Display name too long",
+			request: user.UpdatePublicProfileRequest{DisplayName: strings.Repeat("a", 101)},
+		},
+		{
+			name:    "Bio too long",
+			request: user.UpdatePublicProfileRequest{Bio: strings.Repeat("a", 501)},
+		},
+		{
+			name:    "Avatar URL without scheme",
+			request: user.UpdatePublicProfileRequest{AvatarURL: "example.com/avatar.png"},
+		},
+		{
+			name:    "Avatar URL with another scheme",
+			request: user.UpdatePublicProfileRequest{AvatarURL: "javascript:alert(1)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := updatePublicProfile(t, accessToken, tt.request)
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdatePublicProfile_Unauthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/profile", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdateUserRole godoc
+// @Summary      Update a user's role
+// @Description  Change the role of another user (admin only). The user's current access tokens are invalidated and the new role applies from their next refresh.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId   path      string                  true  "User ID"
+// @Param        request  body      user.UpdateRoleRequest  true  "New role: user, moderator or admin"
+// @Success      200      {object}  server.APIResponse{message=string,result=user.UserProfile}  "Role updated successfully"
+// @Failure      400      {object}  server.APIResponse{message=string,error=string}             "Invalid request body"
+// @Failure      401      {object}  server.APIResponse{message=string,error=string}             "Unauthorized"
+// @Failure      403      {object}  server.APIResponse{message=string,error=string}             "Forbidden - not an admin, or changing own role"
+// @Failure      404      {object}  server.APIResponse{message=string,error=string}             "User not found"
+// @Failure      422      {object}  server.APIResponse{message=string,error=string}             "Invalid role"
+// @Failure      500      {object}  server.APIResponse{message=string,error=string}             "Internal server error"
+// @Router       /api/v1/admin/users/{userId}/role [put]
+func (h *Handler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	claims, ok := server.GetUserClaims(r.Context())
+	if !ok {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	actorID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusUnauthorized, "Invalid user ID", nil)
+		return
+	}
+	actor := policy.Actor{UserID: actorID, Role: claims.Role}
+
+	userID, err := uuid.Parse(r.PathValue("userId"))
+	if err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid user ID", nil)
+		return
+	}
+
+	var req user.UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.ErrorResponse(w, r, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	u, err := h.service.UpdateRole(r.Context(), actor, userID, req)
+	if err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Role updated successfully",
+		Result:  u,
+	})
+}
@@ -0,0 +1,148 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// registerAdmin registers a user, promotes it to admin directly in the
+// database and returns its ID together with an access token carrying the
+// admin role.
+func registerAdmin(t *testing.T, username, email string) (string, string) {
+	t.Helper()
+
+	registerUser(t, username, email)
+	if _, err := testPool.Exec(context.Background(), "UPDATE users SET role = $1 WHERE email = $2", policy.RoleAdmin, email); err != nil {
+		t.Fatalf("Failed to promote admin: %v", err)
+	}
+
+	rec := login(t, email, "Str0ng-Passw0rd")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Login failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse login response: %v", err)
+	}
+	result := response.Result.(map[string]any)
+	return result["user"].(map[string]any)["id"].(string), result["token"].(string)
+}
+
+func updateUserRole(t *testing.T, accessToken, userID string, role policy.Role) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(user.UpdateRoleRequest{Role: role})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/"+userID+"/role", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUpdateUserRole_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	_, adminToken := registerAdmin(t, "admin", "admin@example.com")
+	userToken := registerUser(t, "promoted", "promoted@example.com")
+	userID := getCurrentUser(t, userToken)["id"].(string)
+
+	rec := updateUserRole(t, adminToken, userID, policy.RoleModerator)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Tokens carrying the old role are rejected
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	rec = httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected old access token to be rejected, got %d", rec.Code)
+	}
+
+	rec = login(t, "promoted@example.com", "Str0ng-Passw0rd")
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse login response: %v", err)
+	}
+	newToken := response.Result.(map[string]any)["token"].(string)
+	if role := getCurrentUser(t, newToken)["role"]; role != string(policy.RoleModerator) {
+		t.Errorf("Expected role %q, got %v", policy.RoleModerator, role)
+	}
+}
+
+func TestUpdateUserRole_Forbidden(t *testing.T) {
+	cleanupUsers(t)
+
+	adminID, adminToken := registerAdmin(t, "admin", "admin@example.com")
+	userToken := registerUser(t, "regular", "regular@example.com")
+	userID := getCurrentUser(t, userToken)["id"].(string)
+
+	tests := []struct {
+		name   string
+		token  string
+		userID string
+	}{
+		{
+			name:   "Not an admin",
+			token:  userToken,
+			userID: userID,
+		},
+		{
+			name:   "Own role",
+			token:  adminToken,
+			userID: adminID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := updateUserRole(t, tt.token, tt.userID, policy.RoleAdmin)
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateUserRole_InvalidRole(t *testing.T) {
+	cleanupUsers(t)
+
+	_, adminToken := registerAdmin(t, "admin", "admin@example.com")
+	userToken := registerUser(t, "regular", "regular@example.com")
+	userID := getCurrentUser(t, userToken)["id"].(string)
+
+	rec := updateUserRole(t, adminToken, userID, "superuser")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+}
+
+func TestUpdateUserRole_NotFound(t *testing.T) {
+	cleanupUsers(t)
+
+	_, adminToken := registerAdmin(t, "admin", "admin@example.com")
+
+	rec := updateUserRole(t, adminToken, "00000000-0000-0000-0000-000000000000", policy.RoleModerator)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "TARGET_USER_NOT_FOUND" {
+		t.Errorf("Expected error 'TARGET_USER_NOT_FOUND', got '%v'", response.Error)
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+)
+
+// VerifyEmail godoc
+// @Summary      Verify email
+// @Description  Mark the account email as verified using the token from the verification email
+// @Tags         auth
+// @Produce      json
+// @Param        token  query     string  true  "Email verification token"
+// @Success      200    {object}  server.APIResponse{message=string}               "Email verified successfully"
+// @Failure      400    {object}  server.APIResponse{message=string,error=string}  "Invalid or expired verification token"
+// @Failure      500    {object}  server.APIResponse{message=string,error=string}  "Internal server error"
+// @Router       /api/v1/auth/verify [get]
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.VerifyEmail(r.Context(), r.URL.Query().Get("token")); err != nil {
+		server.HandleError(w, r, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, server.APIResponse{
+		Message: "Email verified successfully",
+	})
+}
@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// registerUser registers a user with the given username and email and
+// returns its access token.
+func registerUser(t *testing.T, username, email string) string {
+	t.Helper()
+
+	body, _ := json.Marshal(user.RegisterRequest{
+		Username: username,
+		Email:    email,
+		Password: "Str0ng-Passw0rd",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Registration failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse register response: %v", err)
+	}
+	return response.Result.(map[string]any)["token"].(string)
+}
+
+func verifyEmail(t *testing.T, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/verify?token="+url.QueryEscape(token), nil)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	return rec
+}
+
+func getCurrentUser(t *testing.T, accessToken string) map[string]any {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+	testServer.Mux().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Get current user failed: %s", rec.Body.String())
+	}
+
+	var response server.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Result.(map[string]any)
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	cleanupUsers(t)
+
+	accessToken := registerUser(t, "verifyuser", "verify@example.com")
+	if getCurrentUser(t, accessToken)["email_verified"] != false {
+		t.Fatal("Expected a new account to have an unverified email")
+	}
+
+	token := tokenFromMessage(t, testMailer.lastMessageTo(t, "verify@example.com"))
+
+	rec := verifyEmail(t, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	if getCurrentUser(t, accessToken)["email_verified"] != true {
+		t.Error("Expected email to be verified")
+	}
+
+	// The verification token is single-use
+	if rec := verifyEmail(t, token); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected reused verification token to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "Empty token",
+			token: "",
+		},
+		{
+			name:  "Unknown token",
+			token: "not-a-real-verification-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := verifyEmail(t, tt.token)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ConsumeLoginChallenge marks the challenge as used. It reports false when
+// it was already used by a concurrent request.
+func (r *Repository) ConsumeLoginChallenge(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE login_challenges SET
+			used_at = NOW()
+		WHERE
+			id = $1
+			AND used_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ConsumeOIDCLoginState deletes and returns the login state, so a callback
+// cannot be replayed. It returns a zero state when there is none.
+func (r *Repository) ConsumeOIDCLoginState(ctx context.Context, stateHash string) (user.OIDCLoginState, error) {
+	query := `
+		DELETE FROM oidc_login_states
+		WHERE
+			state_hash = $1
+		RETURNING
+			id,
+			provider,
+			state_hash,
+			code_verifier,
+			nonce,
+			expires_at,
+			created_at
+	`
+	s := user.OIDCLoginState{}
+	err := r.db.QueryRow(ctx, query, stateHash).Scan(
+		&s.ID,
+		&s.Provider,
+		&s.StateHash,
+		&s.CodeVerifier,
+		&s.Nonce,
+		&s.ExpiresAt,
+		&s.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.OIDCLoginState{}, nil
+	}
+	if err != nil {
+		return user.OIDCLoginState{}, err
+	}
+	return s, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CountEmailVerificationTokensSince returns how many verification emails were
+// issued to the user since the given time, and when the latest one was issued.
+func (r *Repository) CountEmailVerificationTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, sql.NullTime, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			MAX(created_at)
+		FROM email_verification_tokens
+		WHERE
+			user_id = $1
+			AND created_at > $2
+	`
+	var count int
+	var latest sql.NullTime
+	err := r.db.QueryRow(ctx, query, userID, since).Scan(&count, &latest)
+	return count, latest, err
+}
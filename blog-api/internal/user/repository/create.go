@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
@@ -11,18 +12,24 @@ func (r *Repository) Create(ctx context.Context, u user.User) error {
 		INSERT INTO users (
 			id,
 			username,
+			username_normalized,
 			email,
+			email_normalized,
 			password,
+			role,
 			created_at,
 			updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err := r.db.Exec(ctx, query,
 		u.ID,
 		u.Username,
+		normalize.Identifier(u.Username),
 		u.Email,
+		normalize.Identifier(u.Email),
 		u.Password,
+		u.Role,
 		u.CreatedAt,
 		u.UpdatedAt,
 	)
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) CreateEmailVerificationToken(ctx context.Context, t user.EmailVerificationToken) error {
+	query := `
+		INSERT INTO email_verification_tokens (
+			id,
+			user_id,
+			email,
+			token_hash,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(ctx, query,
+		t.ID,
+		t.UserID,
+		t.Email,
+		t.TokenHash,
+		t.ExpiresAt,
+		t.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// upsertIdentityQuery moves the identity to the given user when it was
+// linked before, e.g. to an account that has since been deleted.
+const upsertIdentityQuery = `
+	INSERT INTO user_identities (
+		id,
+		user_id,
+		provider,
+		subject,
+		email,
+		created_at
+	)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (provider, subject) DO UPDATE SET
+		user_id = EXCLUDED.user_id,
+		email = EXCLUDED.email
+`
+
+func (r *Repository) CreateIdentity(ctx context.Context, i user.UserIdentity) error {
+	_, err := r.db.Exec(ctx, upsertIdentityQuery,
+		i.ID,
+		i.UserID,
+		i.Provider,
+		i.Subject,
+		i.Email,
+		i.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) CreateLoginChallenge(ctx context.Context, c user.LoginChallenge) error {
+	query := `
+		INSERT INTO login_challenges (
+			id,
+			user_id,
+			token_hash,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.Exec(ctx, query,
+		c.ID,
+		c.UserID,
+		c.TokenHash,
+		c.ExpiresAt,
+		c.CreatedAt,
+	)
+	return err
+}
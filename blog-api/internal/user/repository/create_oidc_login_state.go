@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// CreateOIDCLoginState stores the state of a login that was redirected to a
+// provider. States of logins that were never completed are removed on the
+// way, so the table does not need a cleanup job.
+func (r *Repository) CreateOIDCLoginState(ctx context.Context, s user.OIDCLoginState) error {
+	deleteExpiredQuery := `
+		DELETE FROM oidc_login_states
+		WHERE
+			expires_at < NOW()
+	`
+	insertQuery := `
+		INSERT INTO oidc_login_states (
+			id,
+			provider,
+			state_hash,
+			code_verifier,
+			nonce,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	if _, err := r.db.Exec(ctx, deleteExpiredQuery); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(ctx, insertQuery,
+		s.ID,
+		s.Provider,
+		s.StateHash,
+		s.CodeVerifier,
+		s.Nonce,
+		s.ExpiresAt,
+		s.CreatedAt,
+	)
+	return err
+}
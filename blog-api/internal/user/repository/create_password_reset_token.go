@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) CreatePasswordResetToken(ctx context.Context, t user.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (
+			id,
+			user_id,
+			token_hash,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.Exec(ctx, query,
+		t.ID,
+		t.UserID,
+		t.TokenHash,
+		t.ExpiresAt,
+		t.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) CreatePersonalAccessToken(ctx context.Context, t user.PersonalAccessToken) error {
+	query := `
+		INSERT INTO personal_access_tokens (
+			id,
+			user_id,
+			name,
+			token_hash,
+			scopes,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query,
+		t.ID,
+		t.UserID,
+		t.Name,
+		t.TokenHash,
+		scopesToStrings(t.Scopes),
+		t.ExpiresAt,
+		t.CreatedAt,
+	)
+	return err
+}
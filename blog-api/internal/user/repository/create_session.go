@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// CreateSession stores a new login together with the first refresh token of
+// its family.
+func (r *Repository) CreateSession(ctx context.Context, s user.Session, t user.RefreshToken) error {
+	createSessionQuery := `
+		INSERT INTO sessions (
+			id,
+			user_id,
+			user_agent,
+			ip_address,
+			created_at,
+			last_seen_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	createRefreshTokenQuery := `
+		INSERT INTO refresh_tokens (
+			id,
+			user_id,
+			family_id,
+			token_hash,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, createSessionQuery,
+			s.ID,
+			s.UserID,
+			s.UserAgent,
+			s.IPAddress,
+			s.CreatedAt,
+			s.LastSeenAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, createRefreshTokenQuery,
+			t.ID,
+			t.UserID,
+			t.FamilyID,
+			t.TokenHash,
+			t.ExpiresAt,
+			t.CreatedAt,
+		)
+		return err
+	})
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// CreateWithIdentity creates a user signing up through a provider together
+// with the link to the provider's account. The email is already verified by
+// the provider.
+func (r *Repository) CreateWithIdentity(ctx context.Context, u user.User, i user.UserIdentity) error {
+	createUserQuery := `
+		INSERT INTO users (
+			id,
+			username,
+			username_normalized,
+			email,
+			email_normalized,
+			password,
+			email_verified_at,
+			role,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, createUserQuery,
+			u.ID,
+			u.Username,
+			normalize.Identifier(u.Username),
+			u.Email,
+			normalize.Identifier(u.Email),
+			u.Password,
+			u.EmailVerifiedAt,
+			u.Role,
+			u.CreatedAt,
+			u.UpdatedAt,
+		); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, upsertIdentityQuery,
+			i.ID,
+			i.UserID,
+			i.Provider,
+			i.Subject,
+			i.Email,
+			i.CreatedAt,
+		)
+		return err
+	})
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+)
+
+func (r *Repository) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	query := `
+		DELETE FROM revoked_tokens
+		WHERE
+			expires_at < NOW()
+	`
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// DisableTOTP removes the user's authenticator app, recovery codes and any
+// pending login challenges.
+func (r *Repository) DisableTOTP(ctx context.Context, userID uuid.UUID) error {
+	disableQuery := `
+		UPDATE users SET
+			totp_secret = NULL,
+			totp_enabled_at = NULL,
+			totp_last_step = NULL,
+			updated_at = NOW()
+		WHERE
+			id = $1
+	`
+	deleteCodesQuery := `
+		DELETE FROM two_factor_recovery_codes
+		WHERE
+			user_id = $1
+	`
+	deleteChallengesQuery := `
+		DELETE FROM login_challenges
+		WHERE
+			user_id = $1
+	`
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, disableQuery, userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, deleteCodesQuery, userID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, deleteChallengesQuery, userID)
+		return err
+	})
+}
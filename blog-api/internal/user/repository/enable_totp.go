@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+var errTOTPNotPending = errors.New("totp enrollment is not pending")
+
+// EnableTOTP finishes the enrollment with the step of the confirmation code
+// and replaces the user's recovery codes. It reports false when there is no
+// pending enrollment or the step was already used.
+func (r *Repository) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, codes []user.RecoveryCode) (bool, error) {
+	enableQuery := `
+		UPDATE users SET
+			totp_enabled_at = NOW(),
+			totp_last_step = $1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+			AND totp_secret IS NOT NULL
+			AND totp_enabled_at IS NULL
+			AND (totp_last_step IS NULL OR totp_last_step < $1)
+			AND deleted_at IS NULL
+	`
+	deleteCodesQuery := `
+		DELETE FROM two_factor_recovery_codes
+		WHERE
+			user_id = $1
+	`
+	insertCodeQuery := `
+		INSERT INTO two_factor_recovery_codes (
+			id,
+			user_id,
+			code_hash,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, enableQuery, step, userID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errTOTPNotPending
+		}
+
+		if _, err := tx.Exec(ctx, deleteCodesQuery, userID); err != nil {
+			return err
+		}
+		for _, c := range codes {
+			if _, err := tx.Exec(ctx, insertCodeQuery, c.ID, c.UserID, c.CodeHash, c.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errTOTPNotPending) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
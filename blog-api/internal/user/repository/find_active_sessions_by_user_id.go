@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// FindActiveSessionsByUserID returns the sessions the user is still signed in
+// with, most recently seen first. A session ends when it is revoked, or when
+// its refresh tokens are, e.g. by a password change, or expire.
+func (r *Repository) FindActiveSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]user.Session, error) {
+	query := `
+		SELECT
+			s.id,
+			s.user_id,
+			s.user_agent,
+			s.ip_address,
+			s.created_at,
+			s.last_seen_at,
+			s.revoked_at
+		FROM sessions s
+		WHERE
+			s.user_id = $1
+			AND s.revoked_at IS NULL
+			AND EXISTS (
+				SELECT 1
+				FROM refresh_tokens rt
+				WHERE
+					rt.family_id = s.id
+					AND rt.revoked_at IS NULL
+					AND rt.replaced_by IS NULL
+					AND rt.expires_at > NOW()
+			)
+		ORDER BY s.last_seen_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []user.Session{}
+	for rows.Next() {
+		s := user.Session{}
+		if err := rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&s.UserAgent,
+			&s.IPAddress,
+			&s.CreatedAt,
+			&s.LastSeenAt,
+			&s.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
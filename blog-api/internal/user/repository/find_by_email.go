@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
@@ -16,19 +17,27 @@ func (r *Repository) FindByEmail(ctx context.Context, email string) (user.User,
 			username,
 			email,
 			password,
+			token_version,
+			email_verified_at,
+			role,
+			totp_enabled_at,
 			created_at,
 			updated_at
 		FROM users
 		WHERE
-			email = $1
+			email_normalized = $1
 			AND deleted_at IS NULL
 	`
 	u := user.User{}
-	err := r.db.QueryRow(ctx, query, email).Scan(
+	err := r.db.QueryRow(ctx, query, normalize.Identifier(email)).Scan(
 		&u.ID,
 		&u.Username,
 		&u.Email,
 		&u.Password,
+		&u.TokenVersion,
+		&u.EmailVerifiedAt,
+		&u.Role,
+		&u.TOTPEnabledAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
@@ -16,20 +17,28 @@ func (r *Repository) FindByEmailOrUsername(ctx context.Context, email, username
 			username,
 			email,
 			password,
+			token_version,
+			email_verified_at,
+			role,
+			totp_enabled_at,
 			created_at,
 			updated_at
 		FROM users
 		WHERE
-			(email = $1 OR username = $2)
+			(email_normalized = $1 OR username_normalized = $2)
 			AND deleted_at IS NULL
 		LIMIT 1
 	`
 	u := user.User{}
-	err := r.db.QueryRow(ctx, query, email, username).Scan(
+	err := r.db.QueryRow(ctx, query, normalize.Identifier(email), normalize.Identifier(username)).Scan(
 		&u.ID,
 		&u.Username,
 		&u.Email,
 		&u.Password,
+		&u.TokenVersion,
+		&u.EmailVerifiedAt,
+		&u.Role,
+		&u.TOTPEnabledAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
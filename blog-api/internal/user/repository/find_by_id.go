@@ -17,6 +17,10 @@ func (r *Repository) FindByID(ctx context.Context, id uuid.UUID) (user.User, err
 			username,
 			email,
 			password,
+			token_version,
+			email_verified_at,
+			role,
+			totp_enabled_at,
 			created_at,
 			updated_at
 		FROM users
@@ -30,6 +34,10 @@ func (r *Repository) FindByID(ctx context.Context, id uuid.UUID) (user.User, err
 		&u.Username,
 		&u.Email,
 		&u.Password,
+		&u.TokenVersion,
+		&u.EmailVerifiedAt,
+		&u.Role,
+		&u.TOTPEnabledAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) FindEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (user.EmailVerificationToken, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			email,
+			token_hash,
+			expires_at,
+			used_at,
+			created_at
+		FROM email_verification_tokens
+		WHERE
+			token_hash = $1
+	`
+	t := user.EmailVerificationToken{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Email,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.UsedAt,
+		&t.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.EmailVerificationToken{}, nil
+	}
+	if err != nil {
+		return user.EmailVerificationToken{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) FindIdentity(ctx context.Context, provider, subject string) (user.UserIdentity, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			provider,
+			subject,
+			email,
+			created_at
+		FROM user_identities
+		WHERE
+			provider = $1
+			AND subject = $2
+	`
+	i := user.UserIdentity{}
+	err := r.db.QueryRow(ctx, query, provider, subject).Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.UserIdentity{}, nil
+	}
+	if err != nil {
+		return user.UserIdentity{}, err
+	}
+	return i, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) FindLoginChallengeByHash(ctx context.Context, tokenHash string) (user.LoginChallenge, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			token_hash,
+			expires_at,
+			used_at,
+			created_at
+		FROM login_challenges
+		WHERE
+			token_hash = $1
+	`
+	c := user.LoginChallenge{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&c.ID,
+		&c.UserID,
+		&c.TokenHash,
+		&c.ExpiresAt,
+		&c.UsedAt,
+		&c.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.LoginChallenge{}, nil
+	}
+	if err != nil {
+		return user.LoginChallenge{}, err
+	}
+	return c, nil
+}
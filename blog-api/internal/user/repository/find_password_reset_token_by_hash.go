@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) FindPasswordResetTokenByHash(ctx context.Context, tokenHash string) (user.PasswordResetToken, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			token_hash,
+			expires_at,
+			used_at,
+			created_at
+		FROM password_reset_tokens
+		WHERE
+			token_hash = $1
+	`
+	t := user.PasswordResetToken{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.UsedAt,
+		&t.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.PasswordResetToken{}, nil
+	}
+	if err != nil {
+		return user.PasswordResetToken{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) FindPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (user.PersonalAccessToken, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			name,
+			token_hash,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM personal_access_tokens
+		WHERE
+			token_hash = $1
+	`
+	t := user.PersonalAccessToken{}
+	var scopes []string
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.TokenHash,
+		&scopes,
+		&t.ExpiresAt,
+		&t.LastUsedAt,
+		&t.RevokedAt,
+		&t.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.PersonalAccessToken{}, nil
+	}
+	if err != nil {
+		return user.PersonalAccessToken{}, err
+	}
+	t.Scopes = stringsToScopes(scopes)
+	return t, nil
+}
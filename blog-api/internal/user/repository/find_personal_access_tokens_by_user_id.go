@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// FindPersonalAccessTokensByUserID returns the user's tokens that are not
+// revoked, newest first. Expired tokens are included so the user can see and
+// clean them up.
+func (r *Repository) FindPersonalAccessTokensByUserID(ctx context.Context, userID uuid.UUID) ([]user.PersonalAccessToken, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			name,
+			token_hash,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM personal_access_tokens
+		WHERE
+			user_id = $1
+			AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []user.PersonalAccessToken{}
+	for rows.Next() {
+		t := user.PersonalAccessToken{}
+		var scopes []string
+		if err := rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&t.Name,
+			&t.TokenHash,
+			&scopes,
+			&t.ExpiresAt,
+			&t.LastUsedAt,
+			&t.RevokedAt,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		t.Scopes = stringsToScopes(scopes)
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// FindPublicProfile returns the public profile of the user with the given
+// username, or a zero profile when there is no such user.
+func (r *Repository) FindPublicProfile(ctx context.Context, username string) (user.PublicProfile, error) {
+	query := `
+		SELECT
+			u.username,
+			u.display_name,
+			u.bio,
+			u.avatar_url,
+			u.created_at,
+			(
+				SELECT COUNT(*)
+				FROM posts p
+				WHERE
+					p.author_id = u.id
+					AND p.status = 'published'
+					AND p.deleted_at IS NULL
+			) AS post_count
+		FROM users u
+		WHERE
+			u.username_normalized = $1
+			AND u.deleted_at IS NULL
+	`
+	p := user.PublicProfile{}
+	err := r.db.QueryRow(ctx, query, normalize.Identifier(username)).Scan(
+		&p.Username,
+		&p.DisplayName,
+		&p.Bio,
+		&p.AvatarURL,
+		&p.JoinedAt,
+		&p.PostCount,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.PublicProfile{}, nil
+	}
+	if err != nil {
+		return user.PublicProfile{}, err
+	}
+	return p, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (user.RefreshToken, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			family_id,
+			token_hash,
+			expires_at,
+			revoked_at,
+			replaced_by,
+			created_at
+		FROM refresh_tokens
+		WHERE
+			token_hash = $1
+	`
+	t := user.RefreshToken{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.FamilyID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.RevokedAt,
+		&t.ReplacedBy,
+		&t.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.RefreshToken{}, nil
+	}
+	if err != nil {
+		return user.RefreshToken{}, err
+	}
+	return t, nil
+}
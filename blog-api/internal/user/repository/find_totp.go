@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// FindTOTP returns the user's authenticator app enrollment, the secret is
+// empty when the user never started one.
+func (r *Repository) FindTOTP(ctx context.Context, userID uuid.UUID) (user.TOTP, error) {
+	query := `
+		SELECT
+			COALESCE(totp_secret, ''),
+			totp_enabled_at,
+			totp_last_step
+		FROM users
+		WHERE
+			id = $1
+			AND deleted_at IS NULL
+	`
+	t := user.TOTP{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&t.Secret,
+		&t.EnabledAt,
+		&t.LastStep,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.TOTP{}, nil
+	}
+	if err != nil {
+		return user.TOTP{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM revoked_tokens WHERE jti = $1
+		)
+	`
+	var revoked bool
+	err := r.db.QueryRow(ctx, query, jti).Scan(&revoked)
+	return revoked, err
+}
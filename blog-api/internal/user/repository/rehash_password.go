@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RehashPassword replaces the hash of an unchanged password with a stronger
+// one. Unlike UpdatePassword it keeps the sessions, and it does nothing when
+// the password was changed since oldHash was read.
+func (r *Repository) RehashPassword(ctx context.Context, userID uuid.UUID, oldHash, newHash string) error {
+	query := `
+		UPDATE users SET
+			password = $1
+		WHERE
+			id = $2
+			AND password = $3
+	`
+	_, err := r.db.Exec(ctx, query, newHash, userID, oldHash)
+	return err
+}
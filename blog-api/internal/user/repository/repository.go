@@ -2,6 +2,8 @@ package repository
 
 import (
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 )
 
 type Repository struct {
@@ -11,3 +13,19 @@ type Repository struct {
 func New(db *pgxpool.Pool) *Repository {
 	return &Repository{db: db}
 }
+
+func scopesToStrings(scopes []policy.Scope) []string {
+	s := make([]string, len(scopes))
+	for i, scope := range scopes {
+		s[i] = string(scope)
+	}
+	return s
+}
+
+func stringsToScopes(s []string) []policy.Scope {
+	scopes := make([]policy.Scope, len(s))
+	for i, scope := range s {
+		scopes[i] = policy.Scope(scope)
+	}
+	return scopes
+}
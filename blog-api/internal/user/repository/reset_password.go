@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+var errResetTokenAlreadyUsed = errors.New("password reset token already used")
+
+// ResetPassword consumes the reset token, stores the new password and signs
+// the user out everywhere in a single transaction: every refresh token is
+// revoked and the token version is bumped so existing access tokens stop
+// validating. It reports false when the token was consumed concurrently.
+func (r *Repository) ResetPassword(ctx context.Context, t user.PasswordResetToken, hashedPassword string) (bool, error) {
+	consumeQuery := `
+		UPDATE password_reset_tokens SET
+			used_at = NOW()
+		WHERE
+			id = $1
+			AND used_at IS NULL
+	`
+	// A link from an older email must not work after the password changed.
+	consumeOthersQuery := `
+		UPDATE password_reset_tokens SET
+			used_at = NOW()
+		WHERE
+			user_id = $1
+			AND used_at IS NULL
+	`
+	updateUserQuery := `
+		UPDATE users SET
+			password = $1,
+			token_version = token_version + 1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+			AND deleted_at IS NULL
+	`
+	revokeRefreshTokensQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			user_id = $1
+			AND revoked_at IS NULL
+	`
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, consumeQuery, t.ID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errResetTokenAlreadyUsed
+		}
+
+		if _, err := tx.Exec(ctx, consumeOthersQuery, t.UserID); err != nil {
+			return err
+		}
+
+		tag, err = tx.Exec(ctx, updateUserQuery, hashedPassword, t.UserID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errResetTokenAlreadyUsed
+		}
+
+		_, err = tx.Exec(ctx, revokeRefreshTokensQuery, t.UserID)
+		return err
+	})
+	if errors.Is(err, errResetTokenAlreadyUsed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RevokeOtherSessions signs the user out of every session but the given one,
+// revoking their refresh tokens in the same transaction. It returns the IDs
+// of the revoked sessions.
+func (r *Repository) RevokeOtherSessions(ctx context.Context, userID, keepSessionID uuid.UUID) ([]uuid.UUID, error) {
+	revokeSessionsQuery := `
+		UPDATE sessions SET
+			revoked_at = NOW()
+		WHERE
+			user_id = $1
+			AND id <> $2
+			AND revoked_at IS NULL
+		RETURNING id
+	`
+	revokeRefreshTokensQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			user_id = $1
+			AND family_id <> $2
+			AND revoked_at IS NULL
+	`
+
+	var revoked []uuid.UUID
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, revokeSessionsQuery, userID, keepSessionID)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			revoked = append(revoked, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, revokeRefreshTokensQuery, userID, keepSessionID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RevokePersonalAccessToken revokes one of the user's tokens. It reports
+// false when the user has no such active token.
+func (r *Repository) RevokePersonalAccessToken(ctx context.Context, userID, tokenID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE personal_access_tokens SET
+			revoked_at = NOW()
+		WHERE
+			id = $1
+			AND user_id = $2
+			AND revoked_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, tokenID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
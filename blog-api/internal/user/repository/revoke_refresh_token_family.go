@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			family_id = $1
+			AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(ctx, query, familyID)
+	return err
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RevokeSession signs the user out of one session, revoking its refresh
+// tokens in the same transaction. It reports false when the user has no such
+// active session.
+func (r *Repository) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	revokeSessionQuery := `
+		UPDATE sessions SET
+			revoked_at = NOW()
+		WHERE
+			id = $1
+			AND user_id = $2
+			AND revoked_at IS NULL
+	`
+	revokeRefreshTokensQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			family_id = $1
+			AND revoked_at IS NULL
+	`
+
+	revoked := false
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, revokeSessionQuery, sessionID, userID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		revoked = true
+
+		_, err = tx.Exec(ctx, revokeRefreshTokensQuery, sessionID)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return revoked, nil
+}
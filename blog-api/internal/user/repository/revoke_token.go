@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (r *Repository) RevokeToken(ctx context.Context, t user.RevokedToken) error {
+	query := `
+		INSERT INTO revoked_tokens (
+			jti,
+			user_id,
+			expires_at,
+			revoked_at
+		)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query,
+		t.JTI,
+		t.UserID,
+		t.ExpiresAt,
+		t.RevokedAt,
+	)
+	return err
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+var errRefreshTokenAlreadyRevoked = errors.New("refresh token already revoked")
+
+// RotateRefreshToken stores the replacement token and revokes the current one
+// in a single transaction. It reports false when the current token was already
+// revoked, which happens when two requests race to use the same token.
+func (r *Repository) RotateRefreshToken(ctx context.Context, currentID uuid.UUID, next user.RefreshToken) (bool, error) {
+	insertQuery := `
+		INSERT INTO refresh_tokens (
+			id,
+			user_id,
+			family_id,
+			token_hash,
+			expires_at,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	revokeQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW(),
+			replaced_by = $1
+		WHERE
+			id = $2
+			AND revoked_at IS NULL
+	`
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, insertQuery,
+			next.ID,
+			next.UserID,
+			next.FamilyID,
+			next.TokenHash,
+			next.ExpiresAt,
+			next.CreatedAt,
+		); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, revokeQuery, next.ID, currentID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errRefreshTokenAlreadyRevoked
+		}
+		return nil
+	})
+	if errors.Is(err, errRefreshTokenAlreadyRevoked) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SetTOTPSecret starts, or restarts, the enrollment of an authenticator app.
+// It reports false when the user does not exist or already has two-factor
+// authentication enabled.
+func (r *Repository) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) (bool, error) {
+	query := `
+		UPDATE users SET
+			totp_secret = $1,
+			totp_last_step = NULL,
+			updated_at = NOW()
+		WHERE
+			id = $2
+			AND totp_enabled_at IS NULL
+			AND deleted_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, secret, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
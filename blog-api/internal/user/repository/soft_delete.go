@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SoftDelete marks the user as deleted and signs them out everywhere in a
+// single transaction. With deleteContent the user's posts and comments are
+// soft deleted as well, otherwise they stay and are shown under a deleted
+// user placeholder. It reports false when the user does not exist.
+func (r *Repository) SoftDelete(ctx context.Context, userID uuid.UUID, deleteContent bool) (bool, error) {
+	deleteUserQuery := `
+		UPDATE users SET
+			token_version = token_version + 1,
+			updated_at = NOW(),
+			deleted_at = NOW()
+		WHERE
+			id = $1
+			AND deleted_at IS NULL
+	`
+	revokeRefreshTokensQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			user_id = $1
+			AND revoked_at IS NULL
+	`
+	deletePostsQuery := `
+		UPDATE posts SET
+			deleted_at = NOW()
+		WHERE
+			author_id = $1
+			AND deleted_at IS NULL
+	`
+	deleteCommentsQuery := `
+		UPDATE comments SET
+			deleted_at = NOW()
+		WHERE
+			author_id = $1
+			AND deleted_at IS NULL
+	`
+
+	deleted := false
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, deleteUserQuery, userID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		deleted = true
+
+		if _, err := tx.Exec(ctx, revokeRefreshTokensQuery, userID); err != nil {
+			return err
+		}
+
+		if !deleteContent {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, deletePostsQuery, userID); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, deleteCommentsQuery, userID)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TouchPersonalAccessToken records that the token was used. The write is
+// skipped when it was already recorded within the last minute, so a busy
+// script does not update the row on every request.
+func (r *Repository) TouchPersonalAccessToken(ctx context.Context, tokenID uuid.UUID) error {
+	query := `
+		UPDATE personal_access_tokens SET
+			last_used_at = NOW()
+		WHERE
+			id = $1
+			AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+	_, err := r.db.Exec(ctx, query, tokenID)
+	return err
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TouchSession records that the session was used and reports whether it is
+// still active, i.e. exists and was not revoked.
+func (r *Repository) TouchSession(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE sessions SET
+			last_seen_at = NOW()
+		WHERE
+			id = $1
+			AND revoked_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, sessionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
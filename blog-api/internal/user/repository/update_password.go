@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdatePassword stores the new password and signs the user out everywhere
+// in a single transaction, the same way ResetPassword does. Pending password
+// reset links are consumed too. It returns the updated user, or a zero user
+// when it does not exist.
+func (r *Repository) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) (user.User, error) {
+	updateUserQuery := `
+		UPDATE users SET
+			password = $1,
+			token_version = token_version + 1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+			AND deleted_at IS NULL
+		RETURNING
+			id,
+			username,
+			email,
+			password,
+			token_version,
+			email_verified_at,
+			role,
+			totp_enabled_at,
+			created_at,
+			updated_at
+	`
+	consumeResetTokensQuery := `
+		UPDATE password_reset_tokens SET
+			used_at = NOW()
+		WHERE
+			user_id = $1
+			AND used_at IS NULL
+	`
+	revokeRefreshTokensQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			user_id = $1
+			AND revoked_at IS NULL
+	`
+
+	updated := user.User{}
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, updateUserQuery, hashedPassword, userID).Scan(
+			&updated.ID,
+			&updated.Username,
+			&updated.Email,
+			&updated.Password,
+			&updated.TokenVersion,
+			&updated.EmailVerifiedAt,
+			&updated.Role,
+			&updated.TOTPEnabledAt,
+			&updated.CreatedAt,
+			&updated.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, consumeResetTokensQuery, userID); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, revokeRefreshTokensQuery, userID)
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.User{}, nil
+	}
+	if err != nil {
+		return user.User{}, err
+	}
+	return updated, nil
+}
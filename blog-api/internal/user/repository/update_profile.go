@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdateProfile stores the user's username, email and email verification
+// state. With signOut, the token version is bumped and every refresh token is
+// revoked in the same transaction, so tokens carrying the old claims stop
+// working. It returns the updated user, or a zero user when it does not
+// exist.
+func (r *Repository) UpdateProfile(ctx context.Context, u user.User, signOut bool) (user.User, error) {
+	updateUserQuery := `
+		UPDATE users SET
+			username = $1,
+			username_normalized = $2,
+			email = $3,
+			email_normalized = $4,
+			email_verified_at = $5,
+			token_version = token_version + CASE WHEN $7 THEN 1 ELSE 0 END,
+			updated_at = NOW()
+		WHERE
+			id = $6
+			AND deleted_at IS NULL
+		RETURNING
+			id,
+			username,
+			email,
+			password,
+			token_version,
+			email_verified_at,
+			role,
+			totp_enabled_at,
+			created_at,
+			updated_at
+	`
+	revokeRefreshTokensQuery := `
+		UPDATE refresh_tokens SET
+			revoked_at = NOW()
+		WHERE
+			user_id = $1
+			AND revoked_at IS NULL
+	`
+
+	updated := user.User{}
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, updateUserQuery,
+			u.Username,
+			normalize.Identifier(u.Username),
+			u.Email,
+			normalize.Identifier(u.Email),
+			u.EmailVerifiedAt,
+			u.ID,
+			signOut,
+		).Scan(
+			&updated.ID,
+			&updated.Username,
+			&updated.Email,
+			&updated.Password,
+			&updated.TokenVersion,
+			&updated.EmailVerifiedAt,
+			&updated.Role,
+			&updated.TOTPEnabledAt,
+			&updated.CreatedAt,
+			&updated.UpdatedAt,
+		)
+		if err != nil || !signOut {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, revokeRefreshTokensQuery, u.ID)
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.User{}, nil
+	}
+	if err != nil {
+		return user.User{}, err
+	}
+	return updated, nil
+}
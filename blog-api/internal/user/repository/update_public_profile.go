@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdatePublicProfile stores the profile fields shown to other users. None of
+// them are in the tokens, so unlike UpdateProfile the user stays signed in.
+// It returns the updated profile, or a zero profile when the user does not
+// exist.
+func (r *Repository) UpdatePublicProfile(ctx context.Context, userID uuid.UUID, req user.UpdatePublicProfileRequest) (user.PublicProfile, error) {
+	query := `
+		WITH updated AS (
+			UPDATE users SET
+				display_name = $1,
+				bio = $2,
+				avatar_url = $3,
+				updated_at = NOW()
+			WHERE
+				id = $4
+				AND deleted_at IS NULL
+			RETURNING
+				id,
+				username,
+				display_name,
+				bio,
+				avatar_url,
+				created_at
+		)
+		SELECT
+			u.username,
+			u.display_name,
+			u.bio,
+			u.avatar_url,
+			u.created_at,
+			(
+				SELECT COUNT(*)
+				FROM posts p
+				WHERE
+					p.author_id = u.id
+					AND p.status = 'published'
+					AND p.deleted_at IS NULL
+			) AS post_count
+		FROM updated u
+	`
+	p := user.PublicProfile{}
+	err := r.db.QueryRow(ctx, query,
+		req.DisplayName,
+		req.Bio,
+		req.AvatarURL,
+		userID,
+	).Scan(
+		&p.Username,
+		&p.DisplayName,
+		&p.Bio,
+		&p.AvatarURL,
+		&p.JoinedAt,
+		&p.PostCount,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.PublicProfile{}, nil
+	}
+	if err != nil {
+		return user.PublicProfile{}, err
+	}
+	return p, nil
+}
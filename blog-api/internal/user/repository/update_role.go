@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdateRole changes the user's role and bumps the token version, so access
+// tokens carrying the old role stop working. Refresh tokens are kept and pick
+// up the new role on the next refresh. It returns the updated user, or a zero
+// user when it does not exist.
+func (r *Repository) UpdateRole(ctx context.Context, userID uuid.UUID, role policy.Role) (user.User, error) {
+	query := `
+		UPDATE users SET
+			role = $1,
+			token_version = token_version + 1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+			AND deleted_at IS NULL
+		RETURNING
+			id,
+			username,
+			email,
+			password,
+			token_version,
+			email_verified_at,
+			role,
+			totp_enabled_at,
+			created_at,
+			updated_at
+	`
+	u := user.User{}
+	err := r.db.QueryRow(ctx, query, role, userID).Scan(
+		&u.ID,
+		&u.Username,
+		&u.Email,
+		&u.Password,
+		&u.TokenVersion,
+		&u.EmailVerifiedAt,
+		&u.Role,
+		&u.TOTPEnabledAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.User{}, nil
+	}
+	if err != nil {
+		return user.User{}, err
+	}
+	return u, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UseRecoveryCode consumes one of the user's recovery codes. It reports
+// false when the user has no such unused code.
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+		UPDATE two_factor_recovery_codes SET
+			used_at = NOW()
+		WHERE
+			user_id = $1
+			AND code_hash = $2
+			AND used_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
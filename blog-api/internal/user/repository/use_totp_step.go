@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UseTOTPStep records the time step of an accepted code. It reports false
+// when the same or a later step was already used, so a code cannot be
+// replayed.
+func (r *Repository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE users SET
+			totp_last_step = $1
+		WHERE
+			id = $2
+			AND totp_enabled_at IS NOT NULL
+			AND (totp_last_step IS NULL OR totp_last_step < $1)
+	`
+	tag, err := r.db.Exec(ctx, query, step, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
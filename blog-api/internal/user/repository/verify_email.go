@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+var errVerificationTokenUnusable = errors.New("email verification token can no longer be used")
+
+// VerifyEmail consumes the verification token and marks the user's email as
+// verified. It reports false when the token was consumed concurrently or the
+// user no longer uses the email the token was issued for.
+func (r *Repository) VerifyEmail(ctx context.Context, t user.EmailVerificationToken) (bool, error) {
+	consumeQuery := `
+		UPDATE email_verification_tokens SET
+			used_at = NOW()
+		WHERE
+			id = $1
+			AND used_at IS NULL
+	`
+	verifyQuery := `
+		UPDATE users SET
+			email_verified_at = COALESCE(email_verified_at, NOW()),
+			updated_at = NOW()
+		WHERE
+			id = $1
+			AND email_normalized = $2
+			AND deleted_at IS NULL
+	`
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, consumeQuery, t.ID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errVerificationTokenUnusable
+		}
+
+		tag, err = tx.Exec(ctx, verifyQuery, t.UserID, normalize.Identifier(t.Email))
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errVerificationTokenUnusable
+		}
+		return nil
+	})
+	if errors.Is(err, errVerificationTokenUnusable) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// AuthenticatePersonalAccessToken implements
+// server.PersonalAccessTokenAuthenticator. Tokens are looked up on every
+// request, so revoking one takes effect immediately.
+func (s *Service) AuthenticatePersonalAccessToken(ctx context.Context, token string) (server.UserClaims, error) {
+	t, err := s.repo.FindPersonalAccessTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		return server.UserClaims{}, err
+	}
+	if t.ID == uuid.Nil || t.RevokedAt.Valid || (t.ExpiresAt.Valid && time.Now().After(t.ExpiresAt.Time)) {
+		return server.UserClaims{}, user.ErrInvalidAccessToken
+	}
+
+	u, err := s.repo.FindByID(ctx, t.UserID)
+	if err != nil {
+		return server.UserClaims{}, err
+	}
+	if u == (user.User{}) {
+		return server.UserClaims{}, user.ErrInvalidAccessToken
+	}
+
+	if err := s.repo.TouchPersonalAccessToken(ctx, t.ID); err != nil {
+		return server.UserClaims{}, err
+	}
+
+	return server.UserClaims{
+		TokenID:             t.ID.String(),
+		UserID:              u.ID.String(),
+		Username:            u.Username,
+		Email:               u.Email,
+		EmailVerified:       u.EmailVerifiedAt.Valid,
+		Role:                u.Role,
+		TokenVersion:        u.TokenVersion,
+		PersonalAccessToken: true,
+		Scopes:              t.Scopes,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// expiringCache is a small in-memory cache used to keep the JWT middleware
+// from hitting Postgres on every request. Every entry carries its own expiry.
+type expiringCache[V any] struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry[V]
+}
+
+func newExpiringCache[V any]() *expiringCache[V] {
+	return &expiringCache[V]{
+		entries: make(map[string]cacheEntry[V]),
+	}
+}
+
+func (c *expiringCache[V]) get(key string) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *expiringCache[V]) set(key string, value V, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry[V]{value: value, expiresAt: expiresAt}
+}
+
+func (c *expiringCache[V]) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *expiringCache[V]) purgeExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	purged := 0
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+			purged++
+		}
+	}
+	return purged
+}
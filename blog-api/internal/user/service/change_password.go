@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ChangePassword replaces the password after checking the current one. Every
+// other session is signed out, the caller gets a new token pair.
+func (s *Service) ChangePassword(ctx context.Context, userID uuid.UUID, req user.ChangePasswordRequest, client user.ClientInfo) (user.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if u == (user.User{}) {
+		return user.AuthResponse{}, user.ErrUserNotFound
+	}
+
+	if match, _ := s.passwords.Verify(u.Password, req.CurrentPassword); !match {
+		return user.AuthResponse{}, user.ErrIncorrectPassword
+	}
+	if err := s.checkPassword("new_password", req.NewPassword, u.Username, u.Email); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.NewPassword)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	updated, err := s.repo.UpdatePassword(ctx, u.ID, hashedPassword)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if updated == (user.User{}) {
+		return user.AuthResponse{}, user.ErrUserNotFound
+	}
+	s.tokenVersions.delete(updated.ID.String())
+
+	return s.issueTokens(ctx, updated, client)
+}
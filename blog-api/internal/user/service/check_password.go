@@ -0,0 +1,20 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/password"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+	"github.com/fikryfahrezy/forward/blog-api/internal/validation"
+)
+
+// checkPassword enforces the password policy on a new password of the user
+// with the given username and email, the violations are reported on field.
+func (s *Service) checkPassword(field, newPassword, username, email string) error {
+	v := validation.Validator{}
+	v.MinLength(field, newPassword, s.config.PasswordMinLength)
+	v.Check(!strings.EqualFold(newPassword, username), field, user.CodePasswordMatchesUsername, "must not be the same as the username")
+	v.Check(!strings.EqualFold(newPassword, email), field, user.CodePasswordMatchesEmail, "must not be the same as the email")
+	v.Check(!password.IsCommon(newPassword), field, user.CodePasswordTooCommon, "is too common, choose one that is harder to guess")
+	return v.Err()
+}
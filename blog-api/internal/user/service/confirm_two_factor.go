@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/totp"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+const recoveryCodeCount = 10
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ConfirmTwoFactor enables two-factor authentication once the user proves
+// their authenticator app produces valid codes. It returns the recovery
+// codes, which are only stored hashed.
+func (s *Service) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, req user.ConfirmTwoFactorRequest) (user.RecoveryCodes, error) {
+	if err := req.Validate(); err != nil {
+		return user.RecoveryCodes{}, err
+	}
+
+	t, err := s.repo.FindTOTP(ctx, userID)
+	if err != nil {
+		return user.RecoveryCodes{}, err
+	}
+	if t.EnabledAt.Valid {
+		return user.RecoveryCodes{}, user.ErrTwoFactorAlreadyEnabled
+	}
+	if t.Secret == "" {
+		return user.RecoveryCodes{}, user.ErrTwoFactorNotSetUp
+	}
+
+	step, ok := totp.Validate(t.Secret, req.Code, time.Now())
+	if !ok {
+		return user.RecoveryCodes{}, user.ErrIncorrectTwoFactorCode
+	}
+
+	codes, records, err := newRecoveryCodes(userID)
+	if err != nil {
+		return user.RecoveryCodes{}, err
+	}
+
+	enabled, err := s.repo.EnableTOTP(ctx, userID, step, records)
+	if err != nil {
+		return user.RecoveryCodes{}, err
+	}
+	if !enabled {
+		return user.RecoveryCodes{}, user.ErrIncorrectTwoFactorCode
+	}
+
+	return user.RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// newRecoveryCodes returns the codes shown to the user, formatted as
+// xxxx-xxxx-xxxx-xxxx, and the records to persist.
+func newRecoveryCodes(userID uuid.UUID) ([]string, []user.RecoveryCode, error) {
+	now := time.Now()
+	codes := make([]string, recoveryCodeCount)
+	records := make([]user.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes[i] = raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+		records[i] = user.RecoveryCode{
+			ID:        uuid.Must(uuid.NewV7()),
+			UserID:    userID,
+			CodeHash:  hashRecoveryCode(codes[i]),
+			CreatedAt: now,
+		}
+	}
+	return codes, records, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so the code can be typed
+// the way it is read.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	return hashToken(normalized)
+}
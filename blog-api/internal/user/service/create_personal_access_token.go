@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// CreatePersonalAccessToken issues a long-lived token for scripts. Only its
+// hash is stored, so the token is returned once and never again.
+func (s *Service) CreatePersonalAccessToken(ctx context.Context, userID uuid.UUID, req user.CreatePersonalAccessTokenRequest) (user.CreatedPersonalAccessToken, error) {
+	if err := req.Validate(); err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+	token := server.PersonalAccessTokenPrefix + secret
+
+	t := user.PersonalAccessToken{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    userID,
+		Name:      req.Name,
+		TokenHash: hashToken(token),
+		Scopes:    req.Scopes,
+		CreatedAt: time.Now(),
+	}
+	if req.ExpiresAt != nil {
+		t.ExpiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	if err := s.repo.CreatePersonalAccessToken(ctx, t); err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+
+	return user.CreatedPersonalAccessToken{
+		PersonalAccessTokenItem: t.ToItem(),
+		Token:                   token,
+	}, nil
+}
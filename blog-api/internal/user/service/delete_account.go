@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// DeleteAccount soft deletes the user after confirming their password. The
+// username and email become available to new accounts.
+func (s *Service) DeleteAccount(ctx context.Context, userID uuid.UUID, req user.DeleteAccountRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == (user.User{}) {
+		return user.ErrUserNotFound
+	}
+
+	if match, _ := s.passwords.Verify(u.Password, req.Password); !match {
+		return user.ErrIncorrectPassword
+	}
+
+	deleted, err := s.repo.SoftDelete(ctx, u.ID, s.config.DeleteContentWithAccount)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return user.ErrUserNotFound
+	}
+
+	s.tokenVersions.delete(u.ID.String())
+	return nil
+}
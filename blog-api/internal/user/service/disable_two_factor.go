@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// DisableTwoFactor removes the authenticator app and the recovery codes after
+// confirming the user's password.
+func (s *Service) DisableTwoFactor(ctx context.Context, userID uuid.UUID, req user.DisableTwoFactorRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == (user.User{}) {
+		return user.ErrUserNotFound
+	}
+
+	if match, _ := s.passwords.Verify(u.Password, req.Password); !match {
+		return user.ErrIncorrectPassword
+	}
+	if !u.TOTPEnabledAt.Valid {
+		return user.ErrTwoFactorNotEnabled
+	}
+
+	return s.repo.DisableTOTP(ctx, u.ID)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ForgotPassword emails a password reset link when the email belongs to an
+// account. It returns nil for unknown emails too, so the endpoint cannot be
+// used to find out which emails are registered.
+func (s *Service) ForgotPassword(ctx context.Context, req user.ForgotPasswordRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	u, err := s.repo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if u == (user.User{}) {
+		return nil
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.PasswordResetTokenDuration)
+	if err := s.repo.CreatePasswordResetToken(ctx, user.PasswordResetToken{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    u.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	// A failed send is only logged, answering it with an error only for
+	// registered emails would tell them apart
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires at %s. If you did not request this, you can ignore this email.\n",
+			u.Username,
+			withTokenParam(s.config.PasswordResetURL, token),
+			expiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send password reset email",
+			slog.String("user_id", u.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// withTokenParam appends the token as the `token` query param of rawURL.
+func withTokenParam(rawURL, token string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL + "?token=" + url.QueryEscape(token)
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
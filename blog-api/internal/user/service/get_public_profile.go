@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) GetPublicProfile(ctx context.Context, username string) (user.PublicProfile, error) {
+	p, err := s.repo.FindPublicProfile(ctx, username)
+	if err != nil {
+		return user.PublicProfile{}, err
+	}
+	if p == (user.PublicProfile{}) {
+		return user.PublicProfile{}, user.ErrProfileNotFound
+	}
+	return p, nil
+}
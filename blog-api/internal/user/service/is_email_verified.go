@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// IsEmailVerified implements server.EmailVerificationChecker, so a user who
+// verifies their email can keep using the token they already have.
+func (s *Service) IsEmailVerified(ctx context.Context, userID string) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, nil
+	}
+
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if u == (user.User{}) {
+		return false, nil
+	}
+	return u.EmailVerifiedAt.Valid, nil
+}
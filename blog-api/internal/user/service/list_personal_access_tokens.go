@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) ListPersonalAccessTokens(ctx context.Context, userID uuid.UUID) ([]user.PersonalAccessTokenItem, error) {
+	tokens, err := s.repo.FindPersonalAccessTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]user.PersonalAccessTokenItem, len(tokens))
+	for i, t := range tokens {
+		items[i] = t.ToItem()
+	}
+	return items, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// ListSessions returns the user's signed in sessions, the one the request
+// was made with is marked as current.
+func (s *Service) ListSessions(ctx context.Context, claims server.UserClaims) ([]user.SessionItem, error) {
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, user.ErrInvalidInput
+	}
+
+	sessions, err := s.repo.FindActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]user.SessionItem, len(sessions))
+	for i, session := range sessions {
+		items[i] = session.ToItem(claims.SessionID)
+	}
+	return items, nil
+}
@@ -2,36 +2,91 @@ package service
 
 import (
 	"context"
+	"log/slog"
 
-	"golang.org/x/crypto/bcrypt"
-
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
-func (s *Service) Login(ctx context.Context, req user.LoginRequest) (user.AuthResponse, error) {
+// Login checks the credentials unless the account or the client IP is
+// throttled, in which case a *lockout.LockedError is returned before the
+// password is compared. Accounts with two-factor authentication get a
+// challenge to complete with LoginTwoFactor instead of the tokens.
+func (s *Service) Login(ctx context.Context, req user.LoginRequest, client user.ClientInfo) (user.LoginResult, error) {
 	if err := req.Validate(); err != nil {
-		return user.AuthResponse{}, err
+		return user.LoginResult{}, err
+	}
+
+	// Failures count against the normalized email, so changing its casing
+	// does not get around the throttling.
+	account := normalize.Identifier(req.Email)
+	if err := s.loginGuard.Allow(ctx, account, client.IPAddress); err != nil {
+		return user.LoginResult{}, err
 	}
 
 	u, err := s.repo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return user.AuthResponse{}, err
+		return user.LoginResult{}, err
 	}
 	if u == (user.User{}) {
-		return user.AuthResponse{}, user.ErrInvalidCredentials
+		return user.LoginResult{}, s.loginFailed(ctx, account, client.IPAddress)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(req.Password)); err != nil {
-		return user.AuthResponse{}, user.ErrInvalidCredentials
+	match, rehash := s.passwords.Verify(u.Password, req.Password)
+	if !match {
+		return user.LoginResult{}, s.loginFailed(ctx, account, client.IPAddress)
+	}
+	if rehash {
+		s.rehashPassword(ctx, u, req.Password)
 	}
 
-	token, err := s.jwtGenerator.GenerateToken(u.ID.String(), u.Username, u.Email)
+	// The failures are only forgiven once the second factor is checked too.
+	if !u.TOTPEnabledAt.Valid {
+		if err := s.loginGuard.RecordSuccess(ctx, account); err != nil {
+			return user.LoginResult{}, err
+		}
+	}
+
+	return s.completeLogin(ctx, u, client)
+}
+
+// completeLogin issues the tokens of an authenticated user, or the challenge
+// for the second factor when the account has two-factor authentication.
+func (s *Service) completeLogin(ctx context.Context, u user.User, client user.ClientInfo) (user.LoginResult, error) {
+	if u.TOTPEnabledAt.Valid {
+		challenge, err := s.newLoginChallenge(ctx, u.ID)
+		if err != nil {
+			return user.LoginResult{}, err
+		}
+		return user.LoginResult{Challenge: challenge}, nil
+	}
+
+	auth, err := s.issueTokens(ctx, u, client)
+	if err != nil {
+		return user.LoginResult{}, err
+	}
+	return user.LoginResult{Auth: auth}, nil
+}
+
+// rehashPassword upgrades a hash made with older settings while the plain
+// password is at hand. The old hash still works, so a failure only means
+// trying again on the next login.
+func (s *Service) rehashPassword(ctx context.Context, u user.User, plainPassword string) {
+	hashedPassword, err := s.passwords.Hash(plainPassword)
+	if err == nil {
+		err = s.repo.RehashPassword(ctx, u.ID, u.Password, hashedPassword)
+	}
 	if err != nil {
-		return user.AuthResponse{}, err
+		slog.WarnContext(ctx, "Failed to rehash password",
+			slog.String("user_id", u.ID.String()),
+			slog.String("error", err.Error()),
+		)
 	}
+}
 
-	return user.AuthResponse{
-		Token: token,
-		User:  u,
-	}, nil
+func (s *Service) loginFailed(ctx context.Context, email, clientIP string) error {
+	if err := s.loginGuard.RecordFailure(ctx, email, clientIP); err != nil {
+		return err
+	}
+	return user.ErrInvalidCredentials
 }
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/totp"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// LoginTwoFactor completes the login of a two-factor account with the
+// challenge token from the first step and either a code of the authenticator
+// app or a recovery code. Wrong codes count as failed logins, so the same
+// throttling as for passwords applies.
+func (s *Service) LoginTwoFactor(ctx context.Context, req user.LoginTwoFactorRequest, client user.ClientInfo) (user.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	c, err := s.repo.FindLoginChallengeByHash(ctx, hashToken(req.ChallengeToken))
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if c == (user.LoginChallenge{}) || c.UsedAt.Valid || time.Now().After(c.ExpiresAt) {
+		return user.AuthResponse{}, user.ErrInvalidLoginChallenge
+	}
+
+	u, err := s.repo.FindByID(ctx, c.UserID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if u == (user.User{}) || !u.TOTPEnabledAt.Valid {
+		return user.AuthResponse{}, user.ErrInvalidLoginChallenge
+	}
+
+	account := normalize.Identifier(u.Email)
+	if err := s.loginGuard.Allow(ctx, account, client.IPAddress); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	verified, err := s.verifySecondFactor(ctx, u.ID, req.Code)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if !verified {
+		if err := s.loginGuard.RecordFailure(ctx, account, client.IPAddress); err != nil {
+			return user.AuthResponse{}, err
+		}
+		return user.AuthResponse{}, user.ErrInvalidTwoFactorCode
+	}
+
+	consumed, err := s.repo.ConsumeLoginChallenge(ctx, c.ID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if !consumed {
+		return user.AuthResponse{}, user.ErrInvalidLoginChallenge
+	}
+
+	if err := s.loginGuard.RecordSuccess(ctx, account); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	return s.issueTokens(ctx, u, client)
+}
+
+// newLoginChallenge returns the challenge token handed to the client after
+// the password step of a two-factor account.
+func (s *Service) newLoginChallenge(ctx context.Context, userID uuid.UUID) (*user.TwoFactorChallenge, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	c := user.LoginChallenge{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(s.config.LoginChallengeDuration),
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateLoginChallenge(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return &user.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresAt:         c.ExpiresAt,
+	}, nil
+}
+
+// verifySecondFactor accepts a code of the authenticator app that was not
+// used before, or an unused recovery code.
+func (s *Service) verifySecondFactor(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	if !isTOTPCode(code) {
+		return s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	}
+
+	t, err := s.repo.FindTOTP(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := totp.Validate(t.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return s.repo.UseTOTPStep(ctx, userID, step)
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) Logout(ctx context.Context, claims server.UserClaims, req user.LogoutRequest) error {
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return user.ErrInvalidInput
+	}
+
+	jti, err := uuid.Parse(claims.TokenID)
+	if err != nil {
+		return user.ErrInvalidInput
+	}
+
+	if err := s.repo.RevokeToken(ctx, user.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt,
+		RevokedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+	s.revocations.set(claims.TokenID, true, claims.ExpiresAt)
+
+	// Ending the session also revokes its refresh tokens.
+	if sessionID, err := uuid.Parse(claims.SessionID); err == nil {
+		if _, err := s.repo.RevokeSession(ctx, userID, sessionID); err != nil {
+			return err
+		}
+		s.sessions.set(claims.SessionID, true, claims.ExpiresAt)
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	rt, err := s.repo.FindRefreshTokenByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+	// Ignore refresh tokens that are unknown or belong to someone else, the
+	// access token is already revoked at this point.
+	if rt == (user.RefreshToken{}) || rt.UserID != userID {
+		return nil
+	}
+
+	return s.repo.RevokeRefreshTokenFamily(ctx, rt.FamilyID)
+}
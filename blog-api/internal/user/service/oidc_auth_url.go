@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// OIDCAuthURL starts a login with the provider and returns the URL to
+// redirect the user to. The state, nonce and PKCE verifier are kept until
+// the provider redirects back to OIDCCallback.
+func (s *Service) OIDCAuthURL(ctx context.Context, providerName string) (string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", user.ErrUnknownProvider
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	if err := s.repo.CreateOIDCLoginState(ctx, user.OIDCLoginState{
+		ID:           uuid.Must(uuid.NewV7()),
+		Provider:     provider.Name(),
+		StateHash:    hashToken(state),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    now.Add(s.config.OIDCStateDuration),
+		CreatedAt:    now,
+	}); err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
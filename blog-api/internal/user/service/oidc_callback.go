@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// OIDCCallback completes a login with the provider. The provider's account is
+// linked to an existing user with the same verified email, or a new user is
+// created, then the login continues like a password login.
+func (s *Service) OIDCCallback(ctx context.Context, providerName, code, state string, client user.ClientInfo) (user.LoginResult, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return user.LoginResult{}, user.ErrUnknownProvider
+	}
+	if code == "" || state == "" {
+		return user.LoginResult{}, user.ErrInvalidOIDCState
+	}
+
+	loginState, err := s.repo.ConsumeOIDCLoginState(ctx, hashToken(state))
+	if err != nil {
+		return user.LoginResult{}, err
+	}
+	if loginState == (user.OIDCLoginState{}) || loginState.Provider != provider.Name() || time.Now().After(loginState.ExpiresAt) {
+		return user.LoginResult{}, user.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		slog.WarnContext(ctx, "OIDC code exchange failed",
+			slog.String("provider", provider.Name()),
+			slog.String("error", err.Error()),
+		)
+		return user.LoginResult{}, user.ErrOIDCLoginFailed
+	}
+
+	u, err := s.findOrCreateOIDCUser(ctx, provider.Name(), claims)
+	if err != nil {
+		return user.LoginResult{}, err
+	}
+
+	return s.completeLogin(ctx, u, client)
+}
+
+func (s *Service) findOrCreateOIDCUser(ctx context.Context, provider string, claims oidc.Claims) (user.User, error) {
+	identity, err := s.repo.FindIdentity(ctx, provider, claims.Subject)
+	if err != nil {
+		return user.User{}, err
+	}
+	if identity != (user.UserIdentity{}) {
+		u, err := s.repo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			return user.User{}, err
+		}
+		// A deleted user's identity is linked again below.
+		if u != (user.User{}) {
+			return u, nil
+		}
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return user.User{}, user.ErrProviderEmailNotVerified
+	}
+
+	now := time.Now()
+	newIdentity := user.UserIdentity{
+		ID:        uuid.Must(uuid.NewV7()),
+		Provider:  provider,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		CreatedAt: now,
+	}
+
+	existing, err := s.repo.FindByEmail(ctx, claims.Email)
+	if err != nil {
+		return user.User{}, err
+	}
+	if existing != (user.User{}) {
+		// Linking to an unverified account would let whoever registered the
+		// email first take over the provider's login.
+		if !existing.EmailVerifiedAt.Valid {
+			return user.User{}, user.ErrAccountNotLinkable
+		}
+
+		newIdentity.UserID = existing.ID
+		if err := s.repo.CreateIdentity(ctx, newIdentity); err != nil {
+			return user.User{}, err
+		}
+		return existing, nil
+	}
+
+	username, err := s.availableUsername(ctx, claims)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	// The user can only log in through the provider until they set a
+	// password with the forgot password flow.
+	randomPassword, err := generateOpaqueToken()
+	if err != nil {
+		return user.User{}, err
+	}
+	hashedPassword, err := s.passwords.Hash(randomPassword)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	u := user.User{
+		ID:              uuid.Must(uuid.NewV7()),
+		Username:        username,
+		Email:           claims.Email,
+		Password:        hashedPassword,
+		EmailVerifiedAt: sql.NullTime{Time: now, Valid: true},
+		Role:            policy.RoleUser,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	newIdentity.UserID = u.ID
+	if err := s.repo.CreateWithIdentity(ctx, u, newIdentity); err != nil {
+		return user.User{}, err
+	}
+	return u, nil
+}
+
+// availableUsername derives a username from the provider's profile, adding a
+// random suffix when it is already taken.
+func (s *Service) availableUsername(ctx context.Context, claims oidc.Claims) (string, error) {
+	base := sanitizeUsername(claims.PreferredUsername)
+	if base == "" {
+		local, _, _ := strings.Cut(claims.Email, "@")
+		base = sanitizeUsername(local)
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for range 5 {
+		existing, err := s.repo.FindByEmailOrUsername(ctx, claims.Email, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == (user.User{}) {
+			return candidate, nil
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+		suffix := fmt.Sprintf("%04d", n.Int64())
+		candidate = base[:min(len(base), user.MaxUsernameLength-len(suffix))] + suffix
+	}
+	return "", user.ErrUsernameExists
+}
+
+func sanitizeUsername(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '.' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	username := b.String()
+	return username[:min(len(username), user.MaxUsernameLength)]
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) Refresh(ctx context.Context, req user.RefreshRequest) (user.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	current, err := s.repo.FindRefreshTokenByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if current == (user.RefreshToken{}) {
+		return user.AuthResponse{}, user.ErrInvalidRefreshToken
+	}
+
+	// A token that was already rotated is being presented again, so either the
+	// client or an attacker holds a stale copy. End the whole session so
+	// neither of them can keep using it.
+	if current.ReplacedBy.Valid {
+		if err := s.revokeReusedFamily(ctx, current); err != nil {
+			return user.AuthResponse{}, err
+		}
+		return user.AuthResponse{}, user.ErrRefreshTokenReused
+	}
+	if current.RevokedAt.Valid || time.Now().After(current.ExpiresAt) {
+		return user.AuthResponse{}, user.ErrInvalidRefreshToken
+	}
+
+	u, err := s.repo.FindByID(ctx, current.UserID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if u == (user.User{}) {
+		return user.AuthResponse{}, user.ErrInvalidRefreshToken
+	}
+
+	// The session is only missing when it was revoked while the refresh
+	// token was in flight.
+	active, err := s.repo.TouchSession(ctx, current.FamilyID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if !active {
+		return user.AuthResponse{}, user.ErrInvalidRefreshToken
+	}
+
+	refreshToken, next, err := s.newRefreshToken(u.ID, current.FamilyID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	rotated, err := s.repo.RotateRefreshToken(ctx, current.ID, next)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if !rotated {
+		// Another request rotated the same token first.
+		if err := s.revokeReusedFamily(ctx, current); err != nil {
+			return user.AuthResponse{}, err
+		}
+		return user.AuthResponse{}, user.ErrRefreshTokenReused
+	}
+
+	token, err := s.generateAccessToken(u, current.FamilyID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	return user.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         u,
+	}, nil
+}
+
+// revokeReusedFamily revokes the session of the token's family, which revokes
+// its refresh tokens in the same transaction, and evicts it from the cache so
+// the access tokens of the session stop working right away on this instance.
+func (s *Service) revokeReusedFamily(ctx context.Context, current user.RefreshToken) error {
+	if _, err := s.repo.RevokeSession(ctx, current.UserID, current.FamilyID); err != nil {
+		return err
+	}
+	s.sessions.delete(current.FamilyID.String())
+	return nil
+}
@@ -2,31 +2,36 @@ package service
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 )
 
-func (s *Service) Register(ctx context.Context, req user.RegisterRequest) (user.AuthResponse, error) {
+func (s *Service) Register(ctx context.Context, req user.RegisterRequest, client user.ClientInfo) (user.AuthResponse, error) {
 	if err := req.Validate(); err != nil {
 		return user.AuthResponse{}, err
 	}
+	if err := s.checkPassword("password", req.Password, req.Username, req.Email); err != nil {
+		return user.AuthResponse{}, err
+	}
 
 	existingUser, err := s.repo.FindByEmailOrUsername(ctx, req.Email, req.Username)
 	if err != nil {
 		return user.AuthResponse{}, err
 	}
 	if existingUser != (user.User{}) {
-		if existingUser.Email == req.Email {
+		if normalize.Identifier(existingUser.Email) == normalize.Identifier(req.Email) {
 			return user.AuthResponse{}, user.ErrEmailExists
 		}
 		return user.AuthResponse{}, user.ErrUsernameExists
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.passwords.Hash(req.Password)
 	if err != nil {
 		return user.AuthResponse{}, err
 	}
@@ -36,7 +41,8 @@ func (s *Service) Register(ctx context.Context, req user.RegisterRequest) (user.
 		ID:        uuid.Must(uuid.NewV7()),
 		Username:  req.Username,
 		Email:     req.Email,
-		Password:  string(hashedPassword),
+		Password:  hashedPassword,
+		Role:      policy.RoleUser,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -45,13 +51,14 @@ func (s *Service) Register(ctx context.Context, req user.RegisterRequest) (user.
 		return user.AuthResponse{}, err
 	}
 
-	token, err := s.jwtGenerator.GenerateToken(u.ID.String(), u.Username, u.Email)
-	if err != nil {
-		return user.AuthResponse{}, err
+	// The account is usable without a verified email, a failed send should
+	// not fail the registration, the user can ask for another email.
+	if err := s.sendVerificationEmail(ctx, u); err != nil {
+		slog.ErrorContext(ctx, "Failed to send verification email",
+			slog.String("user_id", u.ID.String()),
+			slog.String("error", err.Error()),
+		)
 	}
 
-	return user.AuthResponse{
-		Token: token,
-		User:  u,
-	}, nil
+	return s.issueTokens(ctx, u, client)
 }
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == (user.User{}) {
+		return user.ErrUserNotFound
+	}
+	if u.EmailVerifiedAt.Valid {
+		return user.ErrEmailAlreadyVerified
+	}
+
+	now := time.Now()
+	count, latest, err := s.repo.CountEmailVerificationTokensSince(ctx, u.ID, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if s.config.VerificationResendLimit > 0 && count >= s.config.VerificationResendLimit {
+		return user.ErrTooManyRequests
+	}
+	if latest.Valid && now.Sub(latest.Time) < s.config.VerificationResendCooldown {
+		return user.ErrTooManyRequests
+	}
+
+	return s.sendVerificationEmail(ctx, u)
+}
+
+// sendVerificationEmail issues a verification token bound to the user's
+// current email and mails the link to that address.
+func (s *Service) sendVerificationEmail(ctx context.Context, u user.User) error {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.VerificationTokenDuration)
+	if err := s.repo.CreateEmailVerificationToken(ctx, user.EmailVerificationToken{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    u.ID,
+		Email:     u.Email,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      u.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires at %s. If you did not create an account, you can ignore this email.\n",
+			u.Username,
+			withTokenParam(s.config.VerificationURL, token),
+			expiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) ResetPassword(ctx context.Context, req user.ResetPasswordRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	t, err := s.repo.FindPasswordResetTokenByHash(ctx, hashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if t == (user.PasswordResetToken{}) || t.UsedAt.Valid || time.Now().After(t.ExpiresAt) {
+		return user.ErrInvalidResetToken
+	}
+
+	u, err := s.repo.FindByID(ctx, t.UserID)
+	if err != nil {
+		return err
+	}
+	if u == (user.User{}) {
+		return user.ErrInvalidResetToken
+	}
+	if err := s.checkPassword("new_password", req.NewPassword, u.Username, u.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	reset, err := s.repo.ResetPassword(ctx, t, hashedPassword)
+	if err != nil {
+		return err
+	}
+	if !reset {
+		return user.ErrInvalidResetToken
+	}
+
+	// The token version was bumped, drop the cached one so this instance
+	// rejects the old access tokens right away.
+	s.tokenVersions.delete(t.UserID.String())
+	return nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// RevokeOtherSessions signs the user out everywhere but the session the
+// request was made with. Tokens issued before sessions were tracked have no
+// session, then every session is signed out.
+func (s *Service) RevokeOtherSessions(ctx context.Context, claims server.UserClaims) error {
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return user.ErrInvalidInput
+	}
+
+	keep, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		keep = uuid.Nil
+	}
+
+	revoked, err := s.repo.RevokeOtherSessions(ctx, userID, keep)
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range revoked {
+		s.sessions.delete(sessionID.String())
+	}
+	return nil
+}
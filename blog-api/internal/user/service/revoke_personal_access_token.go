@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) RevokePersonalAccessToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	revoked, err := s.repo.RevokePersonalAccessToken(ctx, userID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return user.ErrAccessTokenNotFound
+	}
+	return nil
+}
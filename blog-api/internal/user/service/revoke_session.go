@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// RevokeSession signs the user out of one of their sessions. Its refresh
+// token stops working right away and its access tokens once the session
+// cache of each instance expires.
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	revoked, err := s.repo.RevokeSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return user.ErrSessionNotFound
+	}
+
+	s.sessions.delete(sessionID.String())
+	return nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunRevocationCleanup periodically removes revoked tokens that have expired
+// anyway, from both Postgres and the in-memory cache. It blocks until ctx is
+// cancelled.
+func (s *Service) RunRevocationCleanup(ctx context.Context) {
+	if s.config.RevocationCleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.RevocationCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.repo.DeleteExpiredRevokedTokens(ctx)
+			if err != nil {
+				slog.Error("Failed to delete expired revoked tokens",
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+
+			purged := s.revocations.purgeExpired() + s.tokenVersions.purgeExpired() + s.sessions.purgeExpired()
+			slog.Debug("Cleaned up expired revoked tokens",
+				slog.Int64("deleted", deleted),
+				slog.Int("purged_from_cache", purged),
+			)
+		}
+	}
+}
@@ -1,21 +1,192 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/lockout"
+	"github.com/fikryfahrezy/forward/blog-api/internal/mailer"
+	"github.com/fikryfahrezy/forward/blog-api/internal/oidc"
+	"github.com/fikryfahrezy/forward/blog-api/internal/password"
 	"github.com/fikryfahrezy/forward/blog-api/internal/server"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
 	"github.com/fikryfahrezy/forward/blog-api/internal/user/repository"
 )
 
+type Config struct {
+	RefreshTokenDuration       time.Duration
+	RevocationCacheTTL         time.Duration
+	RevocationCleanupInterval  time.Duration
+	PasswordResetTokenDuration time.Duration
+	// PasswordResetURL is the page of the client app that lets the user pick
+	// a new password, the reset token is appended as the `token` query param.
+	PasswordResetURL string
+	// VerificationTokenDuration is how long an email verification link
+	// stays valid.
+	VerificationTokenDuration time.Duration
+	// VerificationURL is the page the verification link points to, the token
+	// is appended as the `token` query param.
+	VerificationURL string
+	// VerificationResendCooldown is the minimum time between two verification
+	// emails for the same account.
+	VerificationResendCooldown time.Duration
+	// VerificationResendLimit caps how many verification emails an account
+	// can receive per hour, zero disables the cap.
+	VerificationResendLimit int
+	// DeleteContentWithAccount soft deletes a user's posts and comments when
+	// the account is deleted, instead of keeping them under a deleted user
+	// placeholder.
+	DeleteContentWithAccount bool
+	// TOTPIssuer names the service in the user's authenticator app.
+	TOTPIssuer string
+	// LoginChallengeDuration is how long the challenge token returned by the
+	// first login step of a two-factor account stays valid.
+	LoginChallengeDuration time.Duration
+	// OIDCProviders are the OpenID Connect providers users can log in with.
+	OIDCProviders []*oidc.Provider
+	// OIDCStateDuration is how long a login redirected to a provider can be
+	// completed.
+	OIDCStateDuration time.Duration
+	// PasswordMinLength is the minimum number of characters of a new
+	// password.
+	PasswordMinLength int
+	// PasswordHashing selects how new passwords are hashed, older hashes are
+	// upgraded on the next successful login.
+	PasswordHashing password.Config
+}
+
 type Service struct {
-	jwtGenerator *server.JWTGenerator
-	repo         *repository.Repository
+	config        Config
+	jwtGenerator  *server.JWTGenerator
+	repo          *repository.Repository
+	mailer        mailer.Mailer
+	loginGuard    *lockout.Guard
+	passwords     *password.Hasher
+	providers     map[string]*oidc.Provider
+	revocations   *expiringCache[bool]
+	tokenVersions *expiringCache[int]
+	sessions      *expiringCache[bool]
 }
 
 func New(
+	config Config,
 	jwtGenerator *server.JWTGenerator,
 	repo *repository.Repository,
+	mailer mailer.Mailer,
+	loginGuard *lockout.Guard,
 ) *Service {
+	providers := map[string]*oidc.Provider{}
+	for _, p := range config.OIDCProviders {
+		providers[p.Name()] = p
+	}
+
 	return &Service{
-		jwtGenerator: jwtGenerator,
-		repo:         repo,
+		config:        config,
+		jwtGenerator:  jwtGenerator,
+		repo:          repo,
+		mailer:        mailer,
+		loginGuard:    loginGuard,
+		passwords:     password.NewHasher(config.PasswordHashing),
+		providers:     providers,
+		revocations:   newExpiringCache[bool](),
+		tokenVersions: newExpiringCache[int](),
+		sessions:      newExpiringCache[bool](),
+	}
+}
+
+// issueTokens starts a new session for the user, from the given client, and
+// returns its first refresh token together with a fresh access token.
+func (s *Service) issueTokens(ctx context.Context, u user.User, client user.ClientInfo) (user.AuthResponse, error) {
+	sessionID := uuid.Must(uuid.NewV7())
+	refreshToken, rt, err := s.newRefreshToken(u.ID, sessionID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	session := user.Session{
+		ID:         sessionID,
+		UserID:     u.ID,
+		UserAgent:  truncate(client.UserAgent, maxUserAgentLength),
+		IPAddress:  client.IPAddress,
+		CreatedAt:  rt.CreatedAt,
+		LastSeenAt: rt.CreatedAt,
+	}
+	if err := s.repo.CreateSession(ctx, session, rt); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	token, err := s.generateAccessToken(u, sessionID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	return user.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         u,
+	}, nil
+}
+
+func (s *Service) generateAccessToken(u user.User, sessionID uuid.UUID) (string, error) {
+	return s.jwtGenerator.GenerateToken(server.UserClaims{
+		UserID:        u.ID.String(),
+		Username:      u.Username,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerifiedAt.Valid,
+		Role:          u.Role,
+		TokenVersion:  u.TokenVersion,
+		SessionID:     sessionID.String(),
+	})
+}
+
+// newRefreshToken returns the opaque token handed to the client and the
+// record to persist, which only keeps the token's hash.
+func (s *Service) newRefreshToken(userID, familyID uuid.UUID) (string, user.RefreshToken, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return "", user.RefreshToken{}, err
 	}
+
+	now := time.Now()
+	return token, user.RefreshToken{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(s.config.RefreshTokenDuration),
+		CreatedAt: now,
+	}, nil
+}
+
+const maxUserAgentLength = 512
+
+// truncate cuts s to at most n characters.
+func truncate(s string, n int) string {
+	i := 0
+	for j := range s {
+		if i == n {
+			return s[:j]
+		}
+		i++
+	}
+	return s
+}
+
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
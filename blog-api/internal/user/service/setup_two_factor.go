@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/totp"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// SetupTwoFactor starts enrolling an authenticator app with a new secret.
+// Two-factor authentication is only enabled once the user confirms a code,
+// calling it again before that replaces the secret.
+func (s *Service) SetupTwoFactor(ctx context.Context, userID uuid.UUID) (user.TwoFactorSetup, error) {
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return user.TwoFactorSetup{}, err
+	}
+	if u == (user.User{}) {
+		return user.TwoFactorSetup{}, user.ErrUserNotFound
+	}
+	if u.TOTPEnabledAt.Valid {
+		return user.TwoFactorSetup{}, user.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return user.TwoFactorSetup{}, err
+	}
+
+	set, err := s.repo.SetTOTPSecret(ctx, u.ID, secret)
+	if err != nil {
+		return user.TwoFactorSetup{}, err
+	}
+	if !set {
+		return user.TwoFactorSetup{}, user.ErrTwoFactorAlreadyEnabled
+	}
+
+	return user.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.config.TOTPIssuer, u.Email, secret),
+	}, nil
+}
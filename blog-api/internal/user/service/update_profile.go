@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/normalize"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdateProfile changes the user's username and/or email. A new email signs
+// the user out everywhere, as tokens carrying the old one must not be used,
+// and a new token pair is returned. Otherwise the current session goes on
+// with a new access token carrying the new username, and the client keeps
+// its refresh token.
+func (s *Service) UpdateProfile(ctx context.Context, userID, sessionID uuid.UUID, req user.UpdateProfileRequest, client user.ClientInfo) (user.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if u == (user.User{}) {
+		return user.AuthResponse{}, user.ErrUserNotFound
+	}
+
+	// Only the values that change are looked up, so the user never conflicts
+	// with itself. Changing only the casing keeps the same username or email,
+	// it needs no lookup and keeps the email verified.
+	newUsername := ""
+	if req.Username != "" && normalize.Identifier(req.Username) != normalize.Identifier(u.Username) {
+		newUsername = req.Username
+	}
+	newEmail := ""
+	if req.Email != "" && normalize.Identifier(req.Email) != normalize.Identifier(u.Email) {
+		newEmail = req.Email
+	}
+
+	existingUser, err := s.repo.FindByEmailOrUsername(ctx, newEmail, newUsername)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if existingUser != (user.User{}) {
+		if newEmail != "" && normalize.Identifier(existingUser.Email) == normalize.Identifier(newEmail) {
+			return user.AuthResponse{}, user.ErrEmailExists
+		}
+		return user.AuthResponse{}, user.ErrUsernameExists
+	}
+
+	if req.Username != "" {
+		u.Username = req.Username
+	}
+	if req.Email != "" {
+		u.Email = req.Email
+	}
+	if newEmail != "" {
+		u.EmailVerifiedAt = sql.NullTime{}
+	}
+
+	// Tokens without a session, issued before sessions were tracked, cannot
+	// go on and are replaced too
+	signOut := newEmail != "" || sessionID == uuid.Nil
+	updated, err := s.repo.UpdateProfile(ctx, u, signOut)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+	if updated == (user.User{}) {
+		return user.AuthResponse{}, user.ErrUserNotFound
+	}
+	if !signOut {
+		token, err := s.generateAccessToken(updated, sessionID)
+		if err != nil {
+			return user.AuthResponse{}, err
+		}
+		return user.AuthResponse{Token: token, User: updated}, nil
+	}
+	s.tokenVersions.delete(updated.ID.String())
+
+	if newEmail != "" {
+		if err := s.sendVerificationEmail(ctx, updated); err != nil {
+			slog.ErrorContext(ctx, "Failed to send verification email",
+				slog.String("user_id", updated.ID.String()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return s.issueTokens(ctx, updated, client)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) UpdatePublicProfile(ctx context.Context, userID uuid.UUID, req user.UpdatePublicProfileRequest) (user.PublicProfile, error) {
+	if err := req.Validate(); err != nil {
+		return user.PublicProfile{}, err
+	}
+
+	p, err := s.repo.UpdatePublicProfile(ctx, userID, req)
+	if err != nil {
+		return user.PublicProfile{}, err
+	}
+	if p == (user.PublicProfile{}) {
+		return user.PublicProfile{}, user.ErrUserNotFound
+	}
+	return p, nil
+}
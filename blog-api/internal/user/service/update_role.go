@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/policy"
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+// UpdateRole lets an admin change another user's role. Admins cannot change
+// their own role, so the last admin cannot lock everyone out by accident.
+func (s *Service) UpdateRole(ctx context.Context, actor policy.Actor, userID uuid.UUID, req user.UpdateRoleRequest) (user.UserProfile, error) {
+	if !policy.CanManageRoles(actor) {
+		return user.UserProfile{}, user.ErrForbidden
+	}
+	if err := req.Validate(); err != nil {
+		return user.UserProfile{}, err
+	}
+	if actor.UserID == userID {
+		return user.UserProfile{}, user.ErrCannotChangeOwnRole
+	}
+
+	u, err := s.repo.UpdateRole(ctx, userID, req.Role)
+	if err != nil {
+		return user.UserProfile{}, err
+	}
+	if u == (user.User{}) {
+		return user.UserProfile{}, user.ErrTargetUserNotFound
+	}
+
+	s.tokenVersions.delete(u.ID.String())
+	return u.ToUserProfile(), nil
+}
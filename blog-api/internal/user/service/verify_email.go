@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/fikryfahrezy/forward/blog-api/internal/user"
+)
+
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return user.ErrInvalidVerificationToken
+	}
+
+	t, err := s.repo.FindEmailVerificationTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		return err
+	}
+	if t == (user.EmailVerificationToken{}) || t.UsedAt.Valid || time.Now().After(t.ExpiresAt) {
+		return user.ErrInvalidVerificationToken
+	}
+
+	verified, err := s.repo.VerifyEmail(ctx, t)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return user.ErrInvalidVerificationToken
+	}
+	return nil
+}
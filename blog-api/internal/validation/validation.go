@@ -0,0 +1,111 @@
+// Package validation checks request fields and collects every problem found
+// as a violation of a field, instead of stopping at the first one, so the
+// client can show them all next to the fields at once.
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	appError "github.com/fikryfahrezy/forward/blog-api/internal/error"
+)
+
+// Codes of the built-in rules, requests may use their own codes with Check.
+const (
+	CodeRequired      = "REQUIRED"
+	CodeTooShort      = "TOO_SHORT"
+	CodeTooLong       = "TOO_LONG"
+	CodeInvalidEmail  = "INVALID_EMAIL"
+	CodeInvalidFormat = "INVALID_FORMAT"
+)
+
+// ErrInvalidInput is wrapped by every *Error, so the response keeps the same
+// error code whichever fields are wrong.
+var ErrInvalidInput = appError.New(http.StatusUnprocessableEntity, "INVALID_INPUT", "Invalid input data")
+
+type Violation struct {
+	// Field is the JSON name of the field in the request body.
+	Field   string `json:"field" example:"title"`
+	Code    string `json:"code" example:"TOO_LONG"`
+	Message string `json:"message" example:"must be at most 200 characters"`
+}
+
+// Error is returned by Validator.Err when at least one rule failed.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	problems := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		problems[i] = v.Field + " " + v.Message
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidInput.Error(), strings.Join(problems, ", "))
+}
+
+func (e *Error) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// Validator collects the violations of one request. The rules other than
+// Required pass on empty values, so an empty required field is only reported
+// once.
+type Validator struct {
+	violations []Violation
+}
+
+// Check adds a violation with the given code and message unless ok.
+func (v *Validator) Check(ok bool, field, code, message string) {
+	if !ok {
+		v.violations = append(v.violations, Violation{
+			Field:   field,
+			Code:    code,
+			Message: message,
+		})
+	}
+}
+
+func (v *Validator) Required(field, value string) {
+	v.Check(value != "", field, CodeRequired, "is required")
+}
+
+// MinLength counts characters, like MaxLength.
+func (v *Validator) MinLength(field, value string, min int) {
+	v.Check(utf8.RuneCountInString(value) >= min, field, CodeTooShort, fmt.Sprintf("must be at least %d characters", min))
+}
+
+// MaxLength counts characters, like the length of a VARCHAR column.
+func (v *Validator) MaxLength(field, value string, max int) {
+	v.Check(utf8.RuneCountInString(value) <= max, field, CodeTooLong, fmt.Sprintf("must be at most %d characters", max))
+}
+
+// Email accepts a bare address such as `john@example.com`, without a display
+// name or angle brackets.
+func (v *Validator) Email(field, value string) {
+	if value == "" {
+		return
+	}
+	address, err := mail.ParseAddress(value)
+	v.Check(err == nil && address.Address == strings.TrimSpace(value), field, CodeInvalidEmail, "must be a valid email address")
+}
+
+// Pattern requires the value to match the pattern, description completes
+// the message, e.g. "must only contain letters and digits".
+func (v *Validator) Pattern(field, value string, pattern *regexp.Regexp, description string) {
+	if value == "" {
+		return
+	}
+	v.Check(pattern.MatchString(value), field, CodeInvalidFormat, description)
+}
+
+// Err returns an *Error with the violations found so far, or nil.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &Error{Violations: v.violations}
+}
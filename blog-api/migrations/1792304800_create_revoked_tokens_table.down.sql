@@ -0,0 +1,5 @@
+-- Migration: create_revoked_tokens_table
+-- Created: 2026-10-18T13:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE IF EXISTS revoked_tokens;
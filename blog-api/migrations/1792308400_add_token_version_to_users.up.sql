@@ -0,0 +1,5 @@
+-- Migration: add_token_version_to_users
+-- Created: 2026-10-18T14:26:40+07:00
+
+-- Add your UP migration here
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Migration: create_password_reset_tokens_table
+-- Created: 2026-10-18T14:27:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,7 @@
+-- Migration: add_email_verification
+-- Created: 2026-10-18T15:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
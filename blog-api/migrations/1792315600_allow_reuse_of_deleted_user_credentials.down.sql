@@ -0,0 +1,12 @@
+-- Migration: allow_reuse_of_deleted_user_credentials
+-- Created: 2026-10-18T16:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_users_username;
+DROP INDEX idx_users_email;
+
+CREATE INDEX idx_users_username ON users(username) WHERE deleted_at IS NULL;
+CREATE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
+
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
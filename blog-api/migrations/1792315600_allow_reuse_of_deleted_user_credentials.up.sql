@@ -0,0 +1,14 @@
+-- Migration: allow_reuse_of_deleted_user_credentials
+-- Created: 2026-10-18T16:26:40+07:00
+
+-- Add your UP migration here
+-- Soft deleted users keep their row, so uniqueness only applies to active
+-- accounts and a deleted user's username and email can be registered again.
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+
+DROP INDEX idx_users_username;
+DROP INDEX idx_users_email;
+
+CREATE UNIQUE INDEX idx_users_username ON users(username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Migration: add_role_to_users
+-- Created: 2026-10-18T17:26:40+07:00
+
+-- Add your DOWN migration here
+ALTER TABLE users DROP COLUMN role;
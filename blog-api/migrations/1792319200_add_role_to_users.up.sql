@@ -0,0 +1,6 @@
+-- Migration: add_role_to_users
+-- Created: 2026-10-18T17:26:40+07:00
+
+-- Add your UP migration here
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'moderator', 'admin'));
@@ -0,0 +1,5 @@
+-- Migration: create_login_attempts_table
+-- Created: 2026-10-18T18:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE login_attempts;
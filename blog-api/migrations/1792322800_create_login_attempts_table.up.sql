@@ -0,0 +1,13 @@
+-- Migration: create_login_attempts_table
+-- Created: 2026-10-18T18:26:40+07:00
+
+-- Add your UP migration here
+-- Failed login counters, keyed by account or client IP.
+CREATE TABLE login_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ
+);
+
+CREATE INDEX idx_login_attempts_last_failed_at ON login_attempts(last_failed_at);
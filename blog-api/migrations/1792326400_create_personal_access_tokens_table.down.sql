@@ -0,0 +1,5 @@
+-- Migration: create_personal_access_tokens_table
+-- Created: 2026-10-18T19:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE personal_access_tokens;
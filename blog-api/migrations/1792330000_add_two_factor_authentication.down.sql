@@ -0,0 +1,11 @@
+-- Migration: add_two_factor_authentication
+-- Created: 2026-10-18T20:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+
+ALTER TABLE users
+    DROP COLUMN totp_last_step,
+    DROP COLUMN totp_enabled_at,
+    DROP COLUMN totp_secret;
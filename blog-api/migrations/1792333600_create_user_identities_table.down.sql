@@ -0,0 +1,6 @@
+-- Migration: create_user_identities_table
+-- Created: 2026-10-18T21:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS user_identities;
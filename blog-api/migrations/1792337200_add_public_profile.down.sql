@@ -0,0 +1,11 @@
+-- Migration: add_public_profile
+-- Created: 2026-10-18T22:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_posts_author_id_created_at;
+CREATE INDEX idx_posts_author_id ON posts(author_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE users
+    DROP COLUMN display_name,
+    DROP COLUMN bio,
+    DROP COLUMN avatar_url;
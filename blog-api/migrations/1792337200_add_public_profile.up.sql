@@ -0,0 +1,12 @@
+-- Migration: add_public_profile
+-- Created: 2026-10-18T22:26:40+07:00
+
+-- Add your UP migration here
+ALTER TABLE users
+    ADD COLUMN display_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN bio VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN avatar_url VARCHAR(2048) NOT NULL DEFAULT '';
+
+-- Listing an author's posts filters on the author and sorts by date
+DROP INDEX idx_posts_author_id;
+CREATE INDEX idx_posts_author_id_created_at ON posts(author_id, created_at DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Migration: create_sessions_table
+-- Created: 2026-10-18T23:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE IF EXISTS sessions;
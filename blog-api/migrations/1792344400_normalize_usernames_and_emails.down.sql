@@ -0,0 +1,13 @@
+-- Migration: normalize_usernames_and_emails
+-- Created: 2026-10-19T00:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_users_username_normalized;
+DROP INDEX idx_users_email_normalized;
+
+CREATE UNIQUE INDEX idx_users_username ON users(username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
+
+ALTER TABLE users
+    DROP COLUMN username_normalized,
+    DROP COLUMN email_normalized;
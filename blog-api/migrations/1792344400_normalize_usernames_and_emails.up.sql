@@ -0,0 +1,55 @@
+-- Migration: normalize_usernames_and_emails
+-- Created: 2026-10-19T00:26:40+07:00
+
+-- Add your UP migration here
+-- username and email keep the casing the user typed for display, the
+-- normalized columns hold the form the application compares and keeps
+-- unique, see internal/normalize. lower() follows the database's LC_CTYPE,
+-- which has to be a UTF-8 locale for non-ASCII letters to be folded.
+ALTER TABLE users
+    ADD COLUMN username_normalized TEXT,
+    ADD COLUMN email_normalized TEXT;
+
+UPDATE users SET
+    username_normalized = btrim(lower(normalize(username, NFKC)), E' \t\n\r\f\v'),
+    email_normalized = btrim(lower(normalize(email, NFKC)), E' \t\n\r\f\v');
+
+-- Active accounts that only differ by casing or Unicode form cannot both keep
+-- their username or email. List all of them at once so they can be renamed
+-- or deleted before running the migration again, rather than failing on the
+-- first duplicate when the unique index is created.
+DO $$
+DECLARE
+    collisions TEXT;
+BEGIN
+    SELECT string_agg(format('%s %L is used by %s', kind, value, accounts), E'\n' ORDER BY kind, value)
+    INTO collisions
+    FROM (
+        SELECT 'username' AS kind, username_normalized AS value, string_agg(format('%s (%s)', id, username), ', ' ORDER BY created_at) AS accounts
+        FROM users
+        WHERE deleted_at IS NULL
+        GROUP BY username_normalized
+        HAVING COUNT(*) > 1
+        UNION ALL
+        SELECT 'email', email_normalized, string_agg(format('%s (%s)', id, email), ', ' ORDER BY created_at)
+        FROM users
+        WHERE deleted_at IS NULL
+        GROUP BY email_normalized
+        HAVING COUNT(*) > 1
+    ) c;
+
+    IF collisions IS NOT NULL THEN
+        RAISE EXCEPTION E'Users collide once usernames and emails are normalized:\n%', collisions
+            USING HINT = 'Rename or delete all but one account of each, force the previous migration version and migrate again.';
+    END IF;
+END $$;
+
+ALTER TABLE users
+    ALTER COLUMN username_normalized SET NOT NULL,
+    ALTER COLUMN email_normalized SET NOT NULL;
+
+DROP INDEX idx_users_username;
+DROP INDEX idx_users_email;
+
+CREATE UNIQUE INDEX idx_users_username_normalized ON users(username_normalized) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_users_email_normalized ON users(email_normalized) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Migration: add_status_to_posts
+-- Created: 2026-10-19T01:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_posts_scheduled_published_at;
+
+ALTER TABLE posts
+    DROP CONSTRAINT posts_published_at_check,
+    DROP COLUMN published_at,
+    DROP COLUMN status;
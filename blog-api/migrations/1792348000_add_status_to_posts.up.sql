@@ -0,0 +1,18 @@
+-- Migration: add_status_to_posts
+-- Created: 2026-10-19T01:26:40+07:00
+
+-- Add your UP migration here
+-- Existing posts were public as soon as they were created
+ALTER TABLE posts
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    ADD COLUMN published_at TIMESTAMPTZ;
+
+UPDATE posts SET published_at = created_at;
+
+ALTER TABLE posts
+    ALTER COLUMN status SET DEFAULT 'draft',
+    ADD CONSTRAINT posts_published_at_check CHECK (status = 'draft' OR published_at IS NOT NULL);
+
+-- The scheduler looks for scheduled posts that are due
+CREATE INDEX idx_posts_scheduled_published_at ON posts(published_at) WHERE status = 'scheduled' AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Migration: create_post_revisions_table
+-- Created: 2026-10-19T02:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE post_revisions;
@@ -0,0 +1,18 @@
+-- Migration: create_post_revisions_table
+-- Created: 2026-10-19T02:26:40+07:00
+
+-- Add your UP migration here
+-- Every version of a post, the latest one is the post's current title and
+-- content. Rows are only ever inserted.
+CREATE TABLE post_revisions (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, revision)
+);
+
+-- Existing posts start with their current version
+INSERT INTO post_revisions (post_id, revision, title, content, created_at)
+SELECT id, 1, title, content, updated_at FROM posts;
@@ -0,0 +1,6 @@
+-- Migration: create_tags_tables
+-- Created: 2026-10-19T03:26:40+07:00
+
+-- Add your DOWN migration here
+DROP TABLE post_tags;
+DROP TABLE tags;
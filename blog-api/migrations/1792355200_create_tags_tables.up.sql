@@ -0,0 +1,19 @@
+-- Migration: create_tags_tables
+-- Created: 2026-10-19T03:26:40+07:00
+
+-- Add your UP migration here
+-- Tags are identified by their slug, the name is how it was first written
+CREATE TABLE tags (
+    slug VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE post_tags (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tag_slug VARCHAR(50) NOT NULL REFERENCES tags(slug) ON DELETE CASCADE,
+    PRIMARY KEY (post_id, tag_slug)
+);
+
+-- Listing the posts of a tag starts from the tag
+CREATE INDEX idx_post_tags_tag_slug ON post_tags(tag_slug);
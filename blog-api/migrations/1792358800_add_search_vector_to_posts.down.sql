@@ -0,0 +1,9 @@
+-- Migration: add_search_vector_to_posts
+-- Created: 2026-10-19T04:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_posts_search_vector;
+
+ALTER TABLE posts
+    DROP COLUMN search_vector,
+    DROP COLUMN search_language;
@@ -0,0 +1,16 @@
+-- Migration: add_search_vector_to_posts
+-- Created: 2026-10-19T04:26:40+07:00
+
+-- Add your UP migration here
+-- The language is a column because a generated column can only use
+-- immutable expressions, posts are indexed in the language configured when
+-- they were last saved
+ALTER TABLE posts ADD COLUMN search_language REGCONFIG NOT NULL DEFAULT 'simple';
+
+-- Titles weigh more than content in the ranking
+ALTER TABLE posts ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector(search_language, title), 'A') ||
+    setweight(to_tsvector(search_language, content), 'B')
+) STORED;
+
+CREATE INDEX idx_posts_search_vector ON posts USING GIN (search_vector);
@@ -0,0 +1,9 @@
+-- Migration: add_keyset_pagination_indexes
+-- Created: 2026-10-19T05:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_comments_post_id_created_at_id;
+CREATE INDEX idx_comments_post_id ON comments(post_id) WHERE deleted_at IS NULL;
+
+DROP INDEX idx_posts_created_at_id;
+CREATE INDEX idx_posts_created_at ON posts(created_at DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Migration: add_keyset_pagination_indexes
+-- Created: 2026-10-19T05:26:40+07:00
+
+-- Add your UP migration here
+-- Keyset pages are read in (created_at, id) order
+DROP INDEX idx_posts_created_at;
+CREATE INDEX idx_posts_created_at_id ON posts(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+
+DROP INDEX idx_comments_post_id;
+CREATE INDEX idx_comments_post_id_created_at_id ON comments(post_id, created_at, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,8 @@
+-- Migration: add_post_listing_indexes
+-- Created: 2026-10-19T06:26:40+07:00
+
+-- Add your DOWN migration here
+DROP INDEX idx_posts_title_id;
+DROP INDEX idx_posts_updated_at_id;
+DROP INDEX idx_posts_author_id_created_at_id;
+CREATE INDEX idx_posts_author_id ON posts(author_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Migration: add_post_listing_indexes
+-- Created: 2026-10-19T06:26:40+07:00
+
+-- Add your UP migration here
+-- Back the sort orders of the posts listing, and the author filter combined
+-- with the default order. Sorting by comment count reads every matching post.
+DROP INDEX idx_posts_author_id;
+CREATE INDEX idx_posts_author_id_created_at_id ON posts(author_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_posts_updated_at_id ON posts(updated_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_posts_title_id ON posts(title, id) WHERE deleted_at IS NULL;
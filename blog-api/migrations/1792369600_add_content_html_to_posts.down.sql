@@ -0,0 +1,5 @@
+-- Migration: add_content_html_to_posts
+-- Created: 2026-10-19T07:26:40+07:00
+
+-- Add your DOWN migration here
+ALTER TABLE posts DROP COLUMN content_html;
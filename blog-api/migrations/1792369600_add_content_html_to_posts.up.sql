@@ -0,0 +1,7 @@
+-- Migration: add_content_html_to_posts
+-- Created: 2026-10-19T07:26:40+07:00
+
+-- Add your UP migration here
+-- The rendered and sanitized HTML of the Markdown content, posts saved
+-- before it existed are rendered when read until they are saved again
+ALTER TABLE posts ADD COLUMN content_html TEXT;